@@ -0,0 +1,502 @@
+package gotrycatch
+
+import (
+	"context"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// CatchChain - Matching through error wrapping chains
+// ============================================
+
+// maxChainDepth bounds how deep error chains are walked, protecting against
+// cyclic or pathological Unwrap implementations.
+const maxChainDepth = 100
+
+// findInChain walks v and its wrapped errors depth-first and returns the first value
+// that can be cast to type T. Unwrap() error, Unwrap() []error and Cause() error are followed.
+func findInChain[T any](v interface{}) (T, bool) {
+	return findInChainDepth[T](v, 0)
+}
+
+func findInChainDepth[T any](v interface{}, depth int) (T, bool) {
+	var zero T
+	if v == nil || depth > maxChainDepth {
+		return zero, false
+	}
+
+	if t, ok := v.(T); ok {
+		return t, true
+	}
+
+	// Re-thrown values of any type are reachable, not only errors.
+	if rw, ok := v.(*RethrowError); ok {
+		return findInChainDepth[T](rw.Value, depth+1)
+	}
+
+	for _, inner := range unwrapOnce(v) {
+		if t, ok := findInChainDepth[T](inner, depth+1); ok {
+			return t, true
+		}
+	}
+	return zero, false
+}
+
+// unwrapOnce returns the errors directly wrapped by v, following Unwrap() error
+// and Unwrap() []error. Values that only implement Cause() error, as produced by
+// github.com/pkg/errors and similar libraries, are followed too. Nil entries are skipped.
+func unwrapOnce(v interface{}) []error {
+	var inner []error
+	switch x := v.(type) {
+	case interface{ Unwrap() error }:
+		inner = []error{x.Unwrap()}
+	case interface{ Unwrap() []error }:
+		inner = x.Unwrap()
+	case interface{ Cause() error }:
+		inner = []error{x.Cause()}
+	}
+
+	result := inner[:0:0]
+	for _, err := range inner {
+		if err != nil {
+			result = append(result, err)
+		}
+	}
+	return result
+}
+
+// CatchChain handles panics whose value, or any error wrapped inside it, is of type T.
+// The Unwrap chain is walked depth-first, following Unwrap() error, Unwrap() []error and
+// pkg/errors-style Cause() error, and the handler receives the first matching instance found.
+// Branches of an errors.Join are checked in order, so the first matching branch wins.
+// Values wrapped by a *RethrowError (see WrapRethrows) are reached whatever their type.
+// Returns the same TryBlock to allow chaining multiple Catch calls.
+func CatchChain[T any](tb *TryBlock, handler func(T)) *TryBlock {
+	if tb == nil {
+		debugLog("CatchChain: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchChain: handler is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	noteCatch[T](tb, "CatchChain")
+	if tb.err != nil && !tb.handled {
+		if err, ok := findInChain[T](tb.err); ok {
+			debugLog("CatchChain: found %T in chain of %T, calling handler", err, tb.err)
+			handler(err)
+			tb.handled = true
+		} else {
+			debugLog("CatchChain: no %T found in chain of %T", *new(T), tb.err)
+		}
+	}
+	return tb
+}
+
+// ============================================
+// CatchOnce - Idempotent typed catch
+// ============================================
+
+// CatchOnce handles panics of type T at most once per TryBlock.
+// It is a no-op if the block is already handled. Unlike Catch, the block is marked
+// handled before the handler runs, so a handler that re-enters the chain on the same
+// block (or panics) can never cause the handler to fire a second time.
+// Returns the same TryBlock to allow chaining multiple Catch calls.
+func CatchOnce[T any](tb *TryBlock, handler func(T)) *TryBlock {
+	if tb == nil {
+		debugLog("CatchOnce: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchOnce: handler is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	noteCatch[T](tb, "CatchOnce")
+	if tb.handled {
+		debugLog("CatchOnce: block already handled, skipping")
+		return tb
+	}
+
+	if tb.err != nil {
+		if err, ok := tb.err.(T); ok {
+			debugLog("CatchOnce: type %T matched, calling handler", tb.err)
+			tb.handled = true
+			handler(err)
+		} else {
+			debugLog("CatchOnce: type %T does not match target type %T", tb.err, *new(T))
+		}
+	}
+	return tb
+}
+
+// ============================================
+// CatchStringMatch - Substring matching for string panics
+// ============================================
+
+// CatchStringMatch handles string panics whose message contains substr.
+// The comparison is case-insensitive, which helps migrate legacy code that panics
+// with ad-hoc messages. Non-string panic values are never matched.
+// Returns the same TryBlock to allow chaining multiple Catch calls.
+func CatchStringMatch(tb *TryBlock, substr string, handler func(string)) *TryBlock {
+	if tb == nil {
+		debugLog("CatchStringMatch: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchStringMatch: handler is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	noteCatchTarget(tb, "CatchStringMatch", strconv.Quote(substr))
+	if tb.err != nil && !tb.handled {
+		msg, ok := tb.err.(string)
+		if ok && strings.Contains(strings.ToLower(msg), strings.ToLower(substr)) {
+			debugLog("CatchStringMatch: %q contains %q, calling handler", msg, substr)
+			handler(msg)
+			tb.handled = true
+		} else {
+			debugLog("CatchStringMatch: %T(%v) does not match %q", tb.err, tb.err, substr)
+		}
+	}
+	return tb
+}
+
+// ============================================
+// CatchFinally - Terminal Catch + Finally
+// ============================================
+
+// CatchFinally handles panics of type T and then always runs cleanup, collapsing the
+// common Catch + Finally pair into one terminal call. If the error is still unhandled
+// after the handler, it is re-thrown after cleanup runs, exactly like Finally.
+// A nil cleanup is treated as a no-op, so re-throwing still happens.
+func CatchFinally[T any](tb *TryBlock, handler func(T), cleanup func()) {
+	if cleanup == nil {
+		cleanup = func() {}
+	}
+	Catch[T](tb, handler).Finally(cleanup)
+}
+
+// ============================================
+// CatchMap - Translate and re-throw
+// ============================================
+
+// CatchMap handles panics of type T by translating them into a new value and
+// immediately re-throwing it, so outer layers catch the mapped type instead.
+// The original error is marked handled before the new panic is raised.
+// If transform returns nil, the error is considered handled and nothing is thrown.
+// Returns the same TryBlock when T does not match, to allow chaining.
+func CatchMap[T any](tb *TryBlock, transform func(T) interface{}) *TryBlock {
+	if tb == nil {
+		debugLog("CatchMap: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if transform == nil {
+		debugLog("CatchMap: transform is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	noteCatch[T](tb, "CatchMap")
+	if tb.err != nil && !tb.handled {
+		if err, ok := tb.err.(T); ok {
+			mapped := transform(err)
+			tb.handled = true
+			if mapped != nil {
+				debugLog("CatchMap: mapped %T to %T, re-throwing", tb.err, mapped)
+				Throw(mapped)
+			}
+		} else {
+			debugLog("CatchMap: type %T does not match target type %T", tb.err, *new(T))
+		}
+	}
+	return tb
+}
+
+// ============================================
+// Caught - Boolean typed catch
+// ============================================
+
+// Caught behaves like Catch but reports whether the handler fired, so callers can
+// branch without reassigning the block:
+//
+//	if gotrycatch.Caught[errors.ValidationError](tb, handler) { ... }
+//
+// The TryBlock is updated in place. Returns false for a nil block or handler,
+// an already handled block, or a value that does not match T.
+func Caught[T any](tb *TryBlock, handler func(T)) bool {
+	if tb == nil {
+		debugLog("Caught: TryBlock is nil, returning false")
+		return false
+	}
+
+	if handler == nil {
+		debugLog("Caught: handler is nil, returning false")
+		return false
+	}
+
+	noteCatch[T](tb, "Caught")
+	if tb.err == nil || tb.handled {
+		return false
+	}
+
+	err, ok := tb.err.(T)
+	if !ok {
+		debugLog("Caught: type %T does not match target type %T", tb.err, *new(T))
+		return false
+	}
+
+	debugLog("Caught: type %T matched, calling handler", tb.err)
+	handler(err)
+	tb.handled = true
+	return true
+}
+
+// ============================================
+// CatchAnyTyped - CatchAny with type name
+// ============================================
+
+// CatchAnyTyped handles any unhandled panic like CatchAny, and also passes the value's
+// TypeName to the handler, so generic handlers such as logging middleware can record
+// the type without calling into fmt or reflect themselves.
+// Returns the same TryBlock to allow chaining.
+func CatchAnyTyped(tb *TryBlock, handler func(err interface{}, typeName string)) *TryBlock {
+	if tb == nil {
+		debugLog("CatchAnyTyped: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchAnyTyped: handler is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	if tb.err != nil && !tb.handled {
+		typeName := TypeName(tb.err)
+		debugLog("CatchAnyTyped: handling error of type %s", typeName)
+		handler(tb.err, typeName)
+		tb.handled = true
+		tb.handledByAny = true
+	}
+	return tb
+}
+
+// ============================================
+// CatchReflect - Dynamic dispatch by reflect.Type
+// ============================================
+
+// CatchReflect handles panics whose dynamic type is assignable to t, for cases where
+// the type to catch is only known at runtime, such as handler tables built from config.
+// Interface types match any value implementing them. A nil t never matches.
+// Returns the same TryBlock to allow chaining multiple Catch calls.
+func CatchReflect(tb *TryBlock, t reflect.Type, handler func(interface{})) *TryBlock {
+	if tb == nil {
+		debugLog("CatchReflect: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if handler == nil || t == nil {
+		debugLog("CatchReflect: handler or type is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	noteCatchTarget(tb, "CatchReflect", t.String())
+	if tb.err != nil && !tb.handled {
+		if reflect.TypeOf(tb.err).AssignableTo(t) {
+			debugLog("CatchReflect: type %T assignable to %v, calling handler", tb.err, t)
+			handler(tb.err)
+			tb.handled = true
+		} else {
+			debugLog("CatchReflect: type %T not assignable to %v", tb.err, t)
+		}
+	}
+	return tb
+}
+
+// ============================================
+// CatchObserve - Typed observation without handling
+// ============================================
+
+// CatchObserve calls handler for unhandled panics of type T but leaves the block
+// unhandled, so later typed catches still run and Finally still re-throws if nothing
+// else handles the error. Use it for logging or metrics by type ahead of real handling.
+// Returns the same TryBlock to allow chaining multiple Catch calls.
+func CatchObserve[T any](tb *TryBlock, handler func(T)) *TryBlock {
+	if tb == nil {
+		debugLog("CatchObserve: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchObserve: handler is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	noteCatch[T](tb, "CatchObserve")
+	if tb.err != nil && !tb.handled {
+		if err, ok := tb.err.(T); ok {
+			debugLog("CatchObserve: type %T matched, calling observer", tb.err)
+			handler(err)
+		}
+	}
+	return tb
+}
+
+// ============================================
+// CatchSafe - Protected typed handler
+// ============================================
+
+// CatchSafe handles panics of type T like Catch, but runs the handler under its own
+// recover. If the handler panics, its panic replaces the block's error and the block
+// stays unhandled, so the handler bug surfaces through later catches or Finally instead
+// of escaping the chain or being lost.
+// Returns the same TryBlock to allow chaining multiple Catch calls.
+func CatchSafe[T any](tb *TryBlock, handler func(T)) *TryBlock {
+	if tb == nil {
+		debugLog("CatchSafe: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchSafe: handler is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	noteCatch[T](tb, "CatchSafe")
+	if tb.err != nil && !tb.handled {
+		if err, ok := tb.err.(T); ok {
+			debugLog("CatchSafe: type %T matched, calling handler", tb.err)
+			if handlerErr := recoverFrom(func() { handler(err) }); handlerErr != nil {
+				debugLog("CatchSafe: handler panicked with %T, replacing error", handlerErr)
+				tb.err = handlerErr
+				return tb
+			}
+			tb.handled = true
+		} else {
+			debugLog("CatchSafe: type %T does not match target type %T", tb.err, *new(T))
+		}
+	}
+	return tb
+}
+
+// ============================================
+// CatchCtx - Context-aware typed handler
+// ============================================
+
+// CatchCtx handles panics of type T like Catch, passing ctx to the handler so handlers
+// that do I/O, such as logging to a remote sink, can respect cancellation and deadlines.
+// The handler runs even if ctx is already done; it decides how to react. A nil ctx is
+// replaced with context.Background().
+// Returns the same TryBlock to allow chaining multiple Catch calls.
+func CatchCtx[T any](ctx context.Context, tb *TryBlock, handler func(context.Context, T)) *TryBlock {
+	if tb == nil {
+		debugLog("CatchCtx: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchCtx: handler is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	noteCatch[T](tb, "CatchCtx")
+	if tb.err != nil && !tb.handled {
+		if err, ok := tb.err.(T); ok {
+			debugLog("CatchCtx: type %T matched, calling handler", tb.err)
+			handler(ctx, err)
+			tb.handled = true
+		} else {
+			debugLog("CatchCtx: type %T does not match target type %T", tb.err, *new(T))
+		}
+	}
+	return tb
+}
+
+// ============================================
+// CatchRetryable - Marker interface for transient failures
+// ============================================
+
+// Retryable is a marker for error types whose failures may be transient. Built-in
+// NetworkError and RateLimitError implement it; custom types opt in by adding the method.
+type Retryable interface {
+	Retryable() bool
+}
+
+// CatchRetryable handles panics whose value implements Retryable and reports true, so
+// transient failures of unrelated types can be handled in one place, e.g. by scheduling
+// a retry. Values reporting false are left for later catches.
+// Returns the same TryBlock to allow chaining multiple Catch calls.
+func CatchRetryable(tb *TryBlock, handler func(interface{})) *TryBlock {
+	if tb == nil {
+		debugLog("CatchRetryable: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchRetryable: handler is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	noteCatch[Retryable](tb, "CatchRetryable")
+	if tb.err != nil && !tb.handled {
+		if r, ok := tb.err.(Retryable); ok && r.Retryable() {
+			debugLog("CatchRetryable: %T is retryable, calling handler", tb.err)
+			handler(tb.err)
+			tb.handled = true
+		} else {
+			debugLog("CatchRetryable: %T is not retryable", tb.err)
+		}
+	}
+	return tb
+}
+
+// ============================================
+// CatchField - Field-specific validation handling
+// ============================================
+
+// CatchField handles a ValidationError, thrown as a value or a pointer, whose Field
+// matches field, for field-specific handling such as highlighting a form input.
+// field may be a wildcard pattern in path.Match syntax, e.g. "address.*"; a malformed
+// pattern never matches. Validation errors on other fields are left for later catches.
+// Returns the same TryBlock to allow chaining multiple Catch calls.
+func CatchField(tb *TryBlock, field string, handler func(trycatcherrors.ValidationError)) *TryBlock {
+	if tb == nil {
+		debugLog("CatchField: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchField: handler is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	noteCatchTarget(tb, "CatchField", field)
+	if tb.err != nil && !tb.handled {
+		ve, ok := ValueOf[trycatcherrors.ValidationError](tb.err)
+		if !ok {
+			debugLog("CatchField: %T is not a ValidationError", tb.err)
+			return tb
+		}
+		if matched, _ := path.Match(field, ve.Field); matched {
+			debugLog("CatchField: field %q matched %q, calling handler", ve.Field, field)
+			handler(ve)
+			tb.handled = true
+		} else {
+			debugLog("CatchField: field %q does not match %q", ve.Field, field)
+		}
+	}
+	return tb
+}
@@ -0,0 +1,813 @@
+package gotrycatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// CatchChain 测试
+// ============================================
+
+type multiWrapError struct {
+	msg  string
+	errs []error
+}
+
+func (e *multiWrapError) Error() string   { return e.msg }
+func (e *multiWrapError) Unwrap() []error { return e.errs }
+
+func TestCatchChain_SingleUnwrap(t *testing.T) {
+	validationErr := trycatcherrors.NewValidationError("email", "invalid", 1001)
+
+	tb := Try(func() {
+		panic(fmt.Errorf("request failed: %w", validationErr))
+	})
+
+	var caught trycatcherrors.ValidationError
+	tb = CatchChain[trycatcherrors.ValidationError](tb, func(err trycatcherrors.ValidationError) {
+		caught = err
+	})
+
+	if !tb.IsHandled() {
+		t.Error("Expected handled to be true")
+	}
+	if caught.Field != "email" || caught.Code != 1001 {
+		t.Errorf("Expected matched ValidationError on 'email', got %+v", caught)
+	}
+}
+
+func TestCatchChain_MixedUnwrap(t *testing.T) {
+	dbErr := trycatcherrors.NewDatabaseError("INSERT", "users", errors.New("duplicate key"))
+	validationErr := trycatcherrors.NewValidationError("age", "negative", 1002)
+
+	// single -> multi -> single -> DatabaseError
+	chain := fmt.Errorf("outer: %w", &multiWrapError{
+		msg: "multi",
+		errs: []error{
+			errors.New("unrelated"),
+			fmt.Errorf("inner: %w", dbErr),
+			validationErr,
+		},
+	})
+
+	tb := Try(func() {
+		panic(chain)
+	})
+
+	var dbCaught, validationCaught bool
+	tb = CatchChain[trycatcherrors.ValidationError](tb, func(err trycatcherrors.ValidationError) {
+		validationCaught = true
+		if err.Field != "age" {
+			t.Errorf("Expected field 'age', got %v", err.Field)
+		}
+	})
+	tb = CatchChain[trycatcherrors.DatabaseError](tb, func(err trycatcherrors.DatabaseError) {
+		dbCaught = true
+	})
+
+	if !validationCaught {
+		t.Error("Expected ValidationError handler to be called")
+	}
+	if dbCaught {
+		t.Error("Expected DatabaseError handler not to be called after the block was handled")
+	}
+}
+
+func TestCatchChain_FirstMatchDepthFirst(t *testing.T) {
+	first := trycatcherrors.NewDatabaseError("SELECT", "orders", nil)
+	second := trycatcherrors.NewDatabaseError("UPDATE", "users", nil)
+
+	tb := Try(func() {
+		panic(&multiWrapError{msg: "multi", errs: []error{fmt.Errorf("wrap: %w", first), second}})
+	})
+
+	var caught trycatcherrors.DatabaseError
+	CatchChain[trycatcherrors.DatabaseError](tb, func(err trycatcherrors.DatabaseError) {
+		caught = err
+	})
+
+	if caught.Operation != "SELECT" || caught.Table != "orders" {
+		t.Errorf("Expected first DatabaseError in chain, got %s on %s", caught.Operation, caught.Table)
+	}
+}
+
+func TestCatchChain_ErrorsJoin(t *testing.T) {
+	validationErr := trycatcherrors.NewValidationError("email", "invalid", 1001)
+	dbErr := trycatcherrors.NewDatabaseError("INSERT", "users", nil)
+
+	tb := Try(func() {
+		Throw(errors.Join(validationErr, dbErr))
+	})
+
+	var caught trycatcherrors.DatabaseError
+	tb = CatchChain[trycatcherrors.DatabaseError](tb, func(err trycatcherrors.DatabaseError) {
+		caught = err
+	})
+
+	if !tb.IsHandled() {
+		t.Error("Expected joined DatabaseError to be handled")
+	}
+	if caught.Operation != "INSERT" || caught.Table != "users" {
+		t.Errorf("Expected DatabaseError from the join, got %s on %s", caught.Operation, caught.Table)
+	}
+}
+
+func TestCatchChain_NoMatch(t *testing.T) {
+	tb := Try(func() {
+		panic(fmt.Errorf("wrap: %w", errors.New("plain")))
+	})
+
+	var called bool
+	tb = CatchChain[trycatcherrors.NetworkError](tb, func(err trycatcherrors.NetworkError) {
+		called = true
+	})
+
+	if called {
+		t.Error("Expected handler not to be called")
+	}
+	if tb.IsHandled() {
+		t.Error("Expected handled to be false")
+	}
+}
+
+// causeError mimics a github.com/pkg/errors wrapper, which exposes Cause() instead of Unwrap().
+type causeError struct {
+	msg   string
+	cause error
+}
+
+func (e *causeError) Error() string { return e.msg + ": " + e.cause.Error() }
+func (e *causeError) Cause() error  { return e.cause }
+
+func TestCatchChain_CauseChain(t *testing.T) {
+	dbErr := trycatcherrors.NewDatabaseError("SELECT", "users", errors.New("timeout"))
+
+	// pkg/errors-style wrapper -> fmt wrapper -> pkg/errors-style wrapper -> DatabaseError
+	chain := &causeError{msg: "handler", cause: fmt.Errorf("service: %w", &causeError{msg: "repo", cause: dbErr})}
+
+	tb := Try(func() {
+		panic(chain)
+	})
+
+	var caught trycatcherrors.DatabaseError
+	tb = CatchChain[trycatcherrors.DatabaseError](tb, func(err trycatcherrors.DatabaseError) {
+		caught = err
+	})
+
+	if !tb.IsHandled() {
+		t.Fatal("Expected DatabaseError to be found through Cause() chain")
+	}
+	if caught.Table != "users" {
+		t.Errorf("Expected table 'users', got %s", caught.Table)
+	}
+}
+
+func TestCatchChain_NilCause(t *testing.T) {
+	tb := Try(func() {
+		panic(&causeError{msg: "wrapper", cause: nilCause{}})
+	})
+
+	tb = CatchChain[trycatcherrors.DatabaseError](tb, func(trycatcherrors.DatabaseError) {})
+
+	if tb.IsHandled() {
+		t.Error("Expected no match in chain without DatabaseError")
+	}
+}
+
+// nilCause reports a nil Cause, which must terminate the walk.
+type nilCause struct{}
+
+func (nilCause) Error() string { return "root" }
+func (nilCause) Cause() error  { return nil }
+
+func TestCatchChain_NilCases(t *testing.T) {
+	if tb := CatchChain[string](nil, func(string) {}); tb == nil {
+		t.Error("Expected non-nil TryBlock for nil input")
+	}
+
+	tb := Try(func() { panic("err") })
+	if CatchChain[string](tb, nil) != tb {
+		t.Error("Expected same TryBlock for nil handler")
+	}
+	if tb.IsHandled() {
+		t.Error("Expected handled to be false with nil handler")
+	}
+}
+
+// ============================================
+// CatchOnce 测试
+// ============================================
+
+func TestCatchOnce_FiresOnce(t *testing.T) {
+	var calls int
+	tb := Try(func() { panic("boom") })
+
+	tb = CatchOnce[string](tb, func(err string) { calls++ })
+	tb = CatchOnce[string](tb, func(err string) { calls++ })
+
+	if calls != 1 {
+		t.Errorf("Expected handler to run once, got %d", calls)
+	}
+	if !tb.IsHandled() {
+		t.Error("Expected handled to be true")
+	}
+}
+
+func TestCatchOnce_ReentrantHandler(t *testing.T) {
+	var calls int
+	tb := Try(func() { panic("boom") })
+
+	var handler func(string)
+	handler = func(err string) {
+		calls++
+		CatchOnce[string](tb, handler)
+	}
+	CatchOnce[string](tb, handler)
+
+	if calls != 1 {
+		t.Errorf("Expected re-entrant CatchOnce not to re-run handler, got %d calls", calls)
+	}
+}
+
+func TestCatchOnce_AfterCatch(t *testing.T) {
+	var onceCalled bool
+	tb := Try(func() { panic("boom") })
+
+	tb = Catch[string](tb, func(err string) {})
+	tb = CatchOnce[string](tb, func(err string) { onceCalled = true })
+
+	if onceCalled {
+		t.Error("Expected CatchOnce to be a no-op on an already handled block")
+	}
+}
+
+func TestCatchOnce_NonMatching(t *testing.T) {
+	var called bool
+	tb := Try(func() { panic(42) })
+
+	tb = CatchOnce[string](tb, func(err string) { called = true })
+
+	if called {
+		t.Error("Expected handler not to be called")
+	}
+	if tb.IsHandled() {
+		t.Error("Expected handled to be false")
+	}
+}
+
+// ============================================
+// CatchStringMatch 测试
+// ============================================
+
+func TestCatchStringMatch_Matching(t *testing.T) {
+	var caught string
+	tb := Try(func() { panic("Connection TIMEOUT while reading") })
+
+	tb = CatchStringMatch(tb, "timeout", func(msg string) { caught = msg })
+
+	if caught != "Connection TIMEOUT while reading" {
+		t.Errorf("Expected full message passed to handler, got %q", caught)
+	}
+	if !tb.IsHandled() {
+		t.Error("Expected handled to be true")
+	}
+}
+
+func TestCatchStringMatch_NonMatching(t *testing.T) {
+	var called bool
+	tb := Try(func() { panic("permission denied") })
+
+	tb = CatchStringMatch(tb, "timeout", func(string) { called = true })
+
+	if called {
+		t.Error("Expected handler not to be called")
+	}
+	if tb.IsHandled() {
+		t.Error("Expected handled to be false")
+	}
+}
+
+func TestCatchStringMatch_NonString(t *testing.T) {
+	var called bool
+	tb := Try(func() { panic(errors.New("timeout")) })
+
+	CatchStringMatch(tb, "timeout", func(string) { called = true })
+
+	if called {
+		t.Error("Expected error values not to match")
+	}
+}
+
+func TestCatchStringMatch_Chain(t *testing.T) {
+	var which string
+	tb := Try(func() { panic("disk full") })
+
+	tb = CatchStringMatch(tb, "timeout", func(string) { which = "timeout" })
+	tb = CatchStringMatch(tb, "DISK", func(string) { which = "disk" })
+	tb = CatchStringMatch(tb, "full", func(string) { which = "full" })
+
+	if which != "disk" {
+		t.Errorf("Expected first matching handler to fire, got %q", which)
+	}
+}
+
+// ============================================
+// CatchFinally 测试
+// ============================================
+
+func TestCatchFinally_Matched(t *testing.T) {
+	var caught, cleaned bool
+
+	CatchFinally[string](Try(func() { panic("boom") }),
+		func(err string) { caught = true },
+		func() { cleaned = true })
+
+	if !caught || !cleaned {
+		t.Errorf("Expected handler and cleanup to run, got caught=%v cleaned=%v", caught, cleaned)
+	}
+}
+
+func TestCatchFinally_UnmatchedRethrows(t *testing.T) {
+	var caught, cleaned bool
+
+	outer := Try(func() {
+		CatchFinally[string](Try(func() { panic(42) }),
+			func(err string) { caught = true },
+			func() { cleaned = true })
+	})
+
+	if caught {
+		t.Error("Expected handler not to be called for int panic")
+	}
+	if !cleaned {
+		t.Error("Expected cleanup to run before re-throw")
+	}
+	if outer.GetError() != 42 {
+		t.Errorf("Expected 42 to be re-thrown, got %v", outer.GetError())
+	}
+}
+
+func TestCatchFinally_Clean(t *testing.T) {
+	var caught, cleaned bool
+
+	CatchFinally[string](Try(func() {}),
+		func(err string) { caught = true },
+		func() { cleaned = true })
+
+	if caught {
+		t.Error("Expected handler not to be called")
+	}
+	if !cleaned {
+		t.Error("Expected cleanup to run")
+	}
+}
+
+func TestCatchFinally_NilCleanupStillRethrows(t *testing.T) {
+	outer := Try(func() {
+		CatchFinally[string](Try(func() { panic(42) }), func(string) {}, nil)
+	})
+
+	if outer.GetError() != 42 {
+		t.Errorf("Expected 42 to be re-thrown with nil cleanup, got %v", outer.GetError())
+	}
+}
+
+// ============================================
+// CatchMap 测试
+// ============================================
+
+func TestCatchMap_OuterReceivesMappedType(t *testing.T) {
+	var inner *TryBlock
+	var outerCaught trycatcherrors.BusinessLogicError
+
+	outer := Try(func() {
+		inner = Try(func() {
+			panic(trycatcherrors.NewValidationError("amount", "negative", 1001))
+		})
+		inner = CatchMap[trycatcherrors.ValidationError](inner, func(err trycatcherrors.ValidationError) interface{} {
+			return trycatcherrors.NewBusinessLogicError("order_validation", err.Field+": "+err.Message)
+		})
+	})
+	outer = Catch[trycatcherrors.BusinessLogicError](outer, func(err trycatcherrors.BusinessLogicError) {
+		outerCaught = err
+	})
+
+	if !outer.IsHandled() {
+		t.Fatalf("Expected outer block to catch the mapped BusinessLogicError, got %T", outer.GetError())
+	}
+	if outerCaught.Details != "amount: negative" {
+		t.Errorf("Expected mapped details 'amount: negative', got %q", outerCaught.Details)
+	}
+	if !inner.IsHandled() {
+		t.Error("Expected the original error to be marked handled")
+	}
+}
+
+func TestCatchMap_NonMatching(t *testing.T) {
+	tb := Try(func() { panic(42) })
+
+	tb = CatchMap[string](tb, func(err string) interface{} {
+		return "mapped"
+	})
+
+	if tb.IsHandled() {
+		t.Error("Expected non-matching CatchMap to leave the block unhandled")
+	}
+	if tb.GetError() != 42 {
+		t.Errorf("Expected original error, got %v", tb.GetError())
+	}
+}
+
+func TestCatchMap_NilResultSwallows(t *testing.T) {
+	var tb *TryBlock
+	outer := Try(func() {
+		tb = CatchMap[string](Try(func() { panic("ignored") }), func(string) interface{} { return nil })
+	})
+
+	if outer.HasError() {
+		t.Errorf("Expected nil mapping not to re-throw, got %v", outer.GetError())
+	}
+	if !tb.IsHandled() {
+		t.Error("Expected block to be handled")
+	}
+}
+
+// ============================================
+// Caught 测试
+// ============================================
+
+func TestCaught_Matched(t *testing.T) {
+	var fired bool
+	tb := Try(func() { panic(trycatcherrors.NewValidationError("email", "invalid", 1001)) })
+
+	matched := Caught[trycatcherrors.ValidationError](tb, func(trycatcherrors.ValidationError) { fired = true })
+
+	if matched != fired || !matched {
+		t.Errorf("Expected matched and fired to be true, got matched=%v fired=%v", matched, fired)
+	}
+	if !tb.IsHandled() {
+		t.Error("Expected block to be handled in place")
+	}
+}
+
+func TestCaught_NotMatched(t *testing.T) {
+	var fired bool
+	tb := Try(func() { panic(42) })
+
+	matched := Caught[string](tb, func(string) { fired = true })
+
+	if matched || fired {
+		t.Errorf("Expected matched and fired to be false, got matched=%v fired=%v", matched, fired)
+	}
+	if tb.IsHandled() {
+		t.Error("Expected handled to be false")
+	}
+}
+
+func TestCaught_AlreadyHandled(t *testing.T) {
+	var calls int
+	tb := Try(func() { panic("boom") })
+
+	first := Caught[string](tb, func(string) { calls++ })
+	second := Caught[string](tb, func(string) { calls++ })
+
+	if !first || second {
+		t.Errorf("Expected only first Caught to report a match, got %v and %v", first, second)
+	}
+	if calls != 1 {
+		t.Errorf("Expected handler to run once, got %d", calls)
+	}
+}
+
+func TestCaught_NilCases(t *testing.T) {
+	if Caught[string](nil, func(string) {}) {
+		t.Error("Expected false for nil TryBlock")
+	}
+	if Caught[string](Try(func() { panic("x") }), nil) {
+		t.Error("Expected false for nil handler")
+	}
+	if Caught[string](Try(func() {}), func(string) {}) {
+		t.Error("Expected false for clean block")
+	}
+}
+
+// ============================================
+// CatchAnyTyped 测试
+// ============================================
+
+func TestCatchAnyTyped_TypeNames(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{"boom", "string"},
+		{42, "int"},
+		{errors.New("plain"), "*errors.errorString"},
+		{trycatcherrors.NewValidationError("f", "m", 1), "errors.ValidationError"},
+		{&trycatcherrors.NetworkError{}, "*errors.NetworkError"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			var gotValue interface{}
+			var gotName string
+
+			tb := CatchAnyTyped(Try(func() { panic(tt.value) }), func(err interface{}, typeName string) {
+				gotValue = err
+				gotName = typeName
+			})
+
+			if gotName != tt.want {
+				t.Errorf("Expected type name %s, got %s", tt.want, gotName)
+			}
+			if gotValue == nil {
+				t.Error("Expected value to be passed to handler")
+			}
+			if !tb.IsHandled() {
+				t.Error("Expected handled to be true")
+			}
+		})
+	}
+}
+
+func TestCatchAnyTyped_SkipsHandledAndClean(t *testing.T) {
+	var calls int
+	handler := func(interface{}, string) { calls++ }
+
+	CatchAnyTyped(Try(func() {}), handler)
+	CatchAnyTyped(Catch[string](Try(func() { panic("x") }), func(string) {}), handler)
+
+	if calls != 0 {
+		t.Errorf("Expected handler not to be called, got %d calls", calls)
+	}
+	if CatchAnyTyped(nil, handler) == nil {
+		t.Error("Expected non-nil TryBlock for nil input")
+	}
+}
+
+// ============================================
+// CatchReflect 测试
+// ============================================
+
+func TestCatchReflect_HandlerTable(t *testing.T) {
+	table := []struct {
+		name string
+		typ  reflect.Type
+	}{
+		{"validation", reflect.TypeOf(trycatcherrors.ValidationError{})},
+		{"database", reflect.TypeOf(trycatcherrors.DatabaseError{})},
+		{"string", reflect.TypeOf("")},
+		{"error", reflect.TypeOf((*error)(nil)).Elem()},
+	}
+
+	dispatch := func(v interface{}) string {
+		var fired string
+		tb := Try(func() { panic(v) })
+		for _, entry := range table {
+			name := entry.name
+			tb = CatchReflect(tb, entry.typ, func(interface{}) { fired = name })
+		}
+		return fired
+	}
+
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{trycatcherrors.NewValidationError("f", "m", 1), "validation"},
+		{trycatcherrors.NewDatabaseError("SELECT", "t", nil), "database"},
+		{"boom", "string"},
+		{errors.New("plain"), "error"},
+		{42, ""},
+	}
+
+	for _, tt := range tests {
+		if got := dispatch(tt.value); got != tt.want {
+			t.Errorf("Expected handler %q for %T, got %q", tt.want, tt.value, got)
+		}
+	}
+}
+
+func TestCatchReflect_NilType(t *testing.T) {
+	var called bool
+	tb := CatchReflect(Try(func() { panic("boom") }), nil, func(interface{}) { called = true })
+
+	if called || tb.IsHandled() {
+		t.Error("Expected nil type never to match")
+	}
+}
+
+// ============================================
+// CatchObserve 测试
+// ============================================
+
+func TestCatchObserve_LaterCatchFires(t *testing.T) {
+	var observed, handled bool
+
+	tb := Try(func() { panic(trycatcherrors.NewNetworkError("https://api.example.com", 503)) })
+	tb = CatchObserve[trycatcherrors.NetworkError](tb, func(trycatcherrors.NetworkError) { observed = true })
+
+	if !observed {
+		t.Error("Expected observer to fire")
+	}
+	if tb.IsHandled() {
+		t.Error("Expected observer to leave the block unhandled")
+	}
+
+	Catch[trycatcherrors.NetworkError](tb, func(trycatcherrors.NetworkError) { handled = true })
+	if !handled {
+		t.Error("Expected later Catch to fire")
+	}
+}
+
+func TestCatchObserve_StillRethrows(t *testing.T) {
+	var observed bool
+
+	outer := Try(func() {
+		tb := Try(func() { panic("boom") })
+		CatchObserve[string](tb, func(string) { observed = true }).Finally(func() {})
+	})
+
+	if !observed {
+		t.Error("Expected observer to fire")
+	}
+	if outer.GetError() != "boom" {
+		t.Errorf("Expected 'boom' to be re-thrown, got %v", outer.GetError())
+	}
+}
+
+func TestCatchObserve_SkipsHandled(t *testing.T) {
+	var observed bool
+
+	tb := Catch[string](Try(func() { panic("boom") }), func(string) {})
+	CatchObserve[string](tb, func(string) { observed = true })
+
+	if observed {
+		t.Error("Expected observer not to fire on a handled block")
+	}
+}
+
+// ============================================
+// CatchSafe 测试
+// ============================================
+
+func TestCatchSafe_HandlerPanicSurfaces(t *testing.T) {
+	var laterCaught string
+
+	tb := Try(func() { panic(trycatcherrors.NewValidationError("f", "m", 1)) })
+	tb = CatchSafe[trycatcherrors.ValidationError](tb, func(trycatcherrors.ValidationError) {
+		panic("handler bug")
+	})
+
+	if tb.IsHandled() {
+		t.Error("Expected block to stay unhandled after handler panic")
+	}
+	if tb.GetError() != "handler bug" {
+		t.Errorf("Expected handler panic to replace the error, got %v", tb.GetError())
+	}
+
+	Catch[string](tb, func(msg string) { laterCaught = msg })
+	if laterCaught != "handler bug" {
+		t.Errorf("Expected later Catch to see the handler panic, got %q", laterCaught)
+	}
+}
+
+func TestCatchSafe_RethrowsFromFinally(t *testing.T) {
+	outer := Try(func() {
+		CatchSafe[int](Try(func() { panic(1) }), func(int) { panic(2) }).Finally(func() {})
+	})
+
+	if outer.GetError() != 2 {
+		t.Errorf("Expected handler panic to surface via Finally, got %v", outer.GetError())
+	}
+}
+
+func TestCatchSafe_NormalHandler(t *testing.T) {
+	var called bool
+	tb := CatchSafe[string](Try(func() { panic("boom") }), func(string) { called = true })
+
+	if !called || !tb.IsHandled() {
+		t.Errorf("Expected handler to run and block to be handled, got called=%v handled=%v", called, tb.IsHandled())
+	}
+	if tb.GetError() != "boom" {
+		t.Errorf("Expected original error to remain, got %v", tb.GetError())
+	}
+}
+
+// ============================================
+// CatchCtx 测试
+// ============================================
+
+type ctxKey struct{}
+
+func TestCatchCtx_PassesContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request-42")
+	tb := Try(func() { panic("sink unavailable") })
+
+	var gotID interface{}
+	var gotErr string
+	tb = CatchCtx(ctx, tb, func(ctx context.Context, err string) {
+		gotID = ctx.Value(ctxKey{})
+		gotErr = err
+	})
+
+	if gotID != "request-42" || gotErr != "sink unavailable" {
+		t.Errorf("Expected handler to receive context and error, got %v and %q", gotID, gotErr)
+	}
+	if !tb.IsHandled() {
+		t.Error("Expected block to be handled")
+	}
+}
+
+func TestCatchCtx_NonMatching(t *testing.T) {
+	var called bool
+	tb := CatchCtx(context.Background(), Try(func() { panic(42) }), func(context.Context, string) { called = true })
+
+	if called || tb.IsHandled() {
+		t.Error("Expected handler not to fire for a non-matching type")
+	}
+}
+
+func TestCatchCtx_NilContext(t *testing.T) {
+	var got context.Context
+	CatchCtx(nil, Try(func() { panic("x") }), func(ctx context.Context, _ string) { got = ctx })
+
+	if got == nil {
+		t.Error("Expected nil context to be replaced")
+	}
+}
+
+// ============================================
+// CatchRetryable 测试
+// ============================================
+
+type flakyError struct{ transient bool }
+
+func (e flakyError) Retryable() bool { return e.transient }
+
+func TestCatchRetryable(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  bool
+	}{
+		{"network 503", trycatcherrors.NewNetworkError("https://api.example.com", 503), true},
+		{"network 404", trycatcherrors.NewNetworkError("https://api.example.com", 404), false},
+		{"rate limit", trycatcherrors.NewRateLimitError("api", 10, 11, 1), true},
+		{"custom transient", flakyError{transient: true}, true},
+		{"custom permanent", flakyError{transient: false}, false},
+		{"plain string", "boom", false},
+	}
+	for _, tt := range tests {
+		var fired bool
+		tb := CatchRetryable(Try(func() { panic(tt.value) }), func(interface{}) { fired = true })
+
+		if fired != tt.want || tb.IsHandled() != tt.want {
+			t.Errorf("%s: expected fired=%v, got fired=%v handled=%v", tt.name, tt.want, fired, tb.IsHandled())
+		}
+	}
+}
+
+// ============================================
+// CatchField 测试
+// ============================================
+
+func TestCatchField(t *testing.T) {
+	throwField := func(field string) *TryBlock {
+		return Try(func() { panic(trycatcherrors.NewValidationError(field, "invalid", 1001)) })
+	}
+
+	var fired []string
+	for _, field := range []string{"email", "name", "address.city"} {
+		tb := throwField(field)
+		tb = CatchField(tb, "email", func(e trycatcherrors.ValidationError) { fired = append(fired, "email:"+e.Field) })
+		tb = CatchField(tb, "address.*", func(e trycatcherrors.ValidationError) { fired = append(fired, "address:"+e.Field) })
+		if tb.IsHandled() != (field != "name") {
+			t.Errorf("Field %s: unexpected handled state %v", field, tb.IsHandled())
+		}
+	}
+
+	if len(fired) != 2 || fired[0] != "email:email" || fired[1] != "address:address.city" {
+		t.Errorf("Expected only matching fields to fire, got %v", fired)
+	}
+}
+
+func TestCatchField_PointerAndOtherTypes(t *testing.T) {
+	ve := trycatcherrors.NewValidationError("email", "invalid", 1001)
+	var fired int
+	handler := func(trycatcherrors.ValidationError) { fired++ }
+
+	CatchField(Try(func() { panic(&ve) }), "email", handler)
+	CatchField(Try(func() { panic("email") }), "email", handler)
+	CatchField(Try(func() { panic(ve) }), "[", handler)
+
+	if fired != 1 {
+		t.Errorf("Expected only the pointer ValidationError to fire, got %d", fired)
+	}
+}
@@ -0,0 +1,106 @@
+package gotrycatch
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ============================================
+// CatchT - Test assertions on thrown types
+// ============================================
+
+// TestingT is the subset of *testing.T used by CatchT and the Assert helpers. It lets the package offer test
+// helpers without importing the testing package.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// CatchT asserts in a test that tb captured a panic of type T and then runs assert on it.
+// If nothing panicked or the value has a different type, the test is failed with a
+// message naming the expected and actual types, and assert is not called.
+// A matching block is marked handled. A nil assert only checks the type.
+//
+//	tb := gotrycatch.Try(func() { validate(input) })
+//	gotrycatch.CatchT(t, tb, func(err errors.ValidationError) {
+//		if err.Field != "email" { t.Errorf(...) }
+//	})
+func CatchT[T any](t TestingT, tb *TryBlock, assert func(T)) {
+	t.Helper()
+
+	want := reflect.TypeFor[T]().String()
+	if tb == nil || tb.err == nil {
+		t.Errorf("expected panic of type %s, but nothing panicked", want)
+		return
+	}
+
+	err, ok := tb.err.(T)
+	if !ok {
+		t.Errorf("expected panic of type %s, got %T: %v", want, tb.err, tb.err)
+		return
+	}
+
+	tb.handled = true
+	if assert != nil {
+		assert(err)
+	}
+}
+
+// AssertNoPanic runs fn under Try and fails the test if it panicked, reporting the
+// panic's type and value and, when available, its stack: the panic stack if CaptureStack
+// is enabled, otherwise the stack carried by the error itself.
+func AssertNoPanic(t TestingT, fn func()) {
+	t.Helper()
+
+	tb := Try(fn)
+	if tb.err == nil {
+		return
+	}
+
+	stack := panicStack(tb)
+	if len(stack) == 0 {
+		t.Errorf("unexpected panic %T: %s", tb.err, Describe(tb.err))
+		return
+	}
+	t.Errorf("unexpected panic %T: %s\n\t%s", tb.err, Describe(tb.err), strings.Join(stack, "\n\t"))
+}
+
+// AssertPanics runs fn under Try and asserts that it panicked with a value of type T,
+// which is returned for further checks. If nothing panicked or the value has a different
+// type, the test is failed as in CatchT and the zero value is returned.
+func AssertPanics[T any](t TestingT, fn func()) T {
+	t.Helper()
+
+	var got T
+	CatchT(t, Try(fn), func(err T) { got = err })
+	return got
+}
+
+// ExpectHandled fails the test unless the block captured a panic that has been handled.
+// Returns the same TryBlock to allow chaining.
+func (tb *TryBlock) ExpectHandled(t TestingT) *TryBlock {
+	t.Helper()
+
+	switch {
+	case tb == nil || tb.err == nil:
+		t.Errorf("expected a handled panic, but nothing panicked")
+	case !tb.handled:
+		t.Errorf("expected panic to be handled, but %T is unhandled: %s", tb.err, Describe(tb.err))
+	}
+	return tb
+}
+
+// ExpectClean fails the test if the block captured a panic, handled or not.
+// Returns the same TryBlock to allow chaining.
+func (tb *TryBlock) ExpectClean(t TestingT) *TryBlock {
+	t.Helper()
+
+	if tb != nil && tb.err != nil {
+		state := "unhandled"
+		if tb.handled {
+			state = "handled"
+		}
+		t.Errorf("expected no panic, got %s %T: %s", state, tb.err, Describe(tb.err))
+	}
+	return tb
+}
@@ -0,0 +1,177 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// fakeT records CatchT failures instead of failing the real test.
+type fakeT struct {
+	helperCalls int
+	failures    []string
+}
+
+func (f *fakeT) Helper() { f.helperCalls++ }
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+var _ TestingT = (*testing.T)(nil)
+
+// ============================================
+// CatchT 测试
+// ============================================
+
+func TestCatchT_Match(t *testing.T) {
+	ft := &fakeT{}
+	var asserted string
+
+	tb := Try(func() { panic(trycatcherrors.NewValidationError("email", "invalid", 1001)) })
+	CatchT(ft, tb, func(err trycatcherrors.ValidationError) { asserted = err.Field })
+
+	if len(ft.failures) != 0 {
+		t.Errorf("Expected no failures, got %v", ft.failures)
+	}
+	if asserted != "email" {
+		t.Errorf("Expected assert to receive the error, got %q", asserted)
+	}
+	if ft.helperCalls == 0 {
+		t.Error("Expected CatchT to call Helper")
+	}
+	if !tb.IsHandled() {
+		t.Error("Expected matching block to be handled")
+	}
+}
+
+func TestCatchT_Mismatch(t *testing.T) {
+	ft := &fakeT{}
+	var called bool
+
+	tb := Try(func() { panic("boom") })
+	CatchT(ft, tb, func(trycatcherrors.ValidationError) { called = true })
+
+	if called {
+		t.Error("Expected assert not to run on mismatch")
+	}
+	if len(ft.failures) != 1 || !strings.Contains(ft.failures[0], "errors.ValidationError, got string: boom") {
+		t.Errorf("Expected one failure naming both types, got %v", ft.failures)
+	}
+}
+
+func TestCatchT_NoPanic(t *testing.T) {
+	ft := &fakeT{}
+
+	CatchT[string](ft, Try(func() {}), nil)
+
+	if len(ft.failures) != 1 || !strings.Contains(ft.failures[0], "nothing panicked") {
+		t.Errorf("Expected a no-panic failure, got %v", ft.failures)
+	}
+}
+
+func TestCatchT_RealT(t *testing.T) {
+	CatchT(t, Try(func() { panic(42) }), func(v int) {
+		if v != 42 {
+			t.Errorf("Expected 42, got %d", v)
+		}
+	})
+}
+
+// ============================================
+// AssertNoPanic / AssertPanics 测试
+// ============================================
+
+func TestAssertNoPanic_Clean(t *testing.T) {
+	ft := &fakeT{}
+	var ran bool
+	AssertNoPanic(ft, func() { ran = true })
+
+	if !ran || len(ft.failures) != 0 {
+		t.Errorf("Expected fn to run without failures, got %v", ft.failures)
+	}
+}
+
+func TestAssertNoPanic_Panics(t *testing.T) {
+	ft := &fakeT{}
+	AssertNoPanic(ft, func() { panic("boom") })
+
+	if len(ft.failures) != 1 || !strings.Contains(ft.failures[0], "string: boom") {
+		t.Errorf("Expected failure naming the panic, got %v", ft.failures)
+	}
+}
+
+func TestAssertNoPanic_IncludesStack(t *testing.T) {
+	old := CaptureStack
+	CaptureStack = true
+	defer func() { CaptureStack = old }()
+
+	ft := &fakeT{}
+	AssertNoPanic(ft, func() { panic("boom") })
+
+	if len(ft.failures) != 1 || !strings.Contains(ft.failures[0], "catcht_test.go") {
+		t.Errorf("Expected failure to include the panic stack, got %v", ft.failures)
+	}
+}
+
+func TestAssertPanics(t *testing.T) {
+	ft := &fakeT{}
+	err := AssertPanics[trycatcherrors.ValidationError](ft, func() {
+		panic(trycatcherrors.NewValidationError("email", "invalid", 1001))
+	})
+	if len(ft.failures) != 0 || err.Field != "email" {
+		t.Errorf("Expected matching panic to pass and be returned, got %v, %v", ft.failures, err)
+	}
+
+	ft = &fakeT{}
+	AssertPanics[trycatcherrors.ValidationError](ft, func() { panic("wrong type") })
+	if len(ft.failures) != 1 || !strings.Contains(ft.failures[0], "got string") {
+		t.Errorf("Expected wrong type to fail, got %v", ft.failures)
+	}
+
+	ft = &fakeT{}
+	AssertPanics[string](ft, func() {})
+	if len(ft.failures) != 1 || !strings.Contains(ft.failures[0], "nothing panicked") {
+		t.Errorf("Expected missing panic to fail, got %v", ft.failures)
+	}
+}
+
+// ============================================
+// ExpectHandled / ExpectClean 测试
+// ============================================
+
+func TestExpectHandled(t *testing.T) {
+	ft := &fakeT{}
+	Catch[string](Try(func() { panic("x") }), func(string) {}).ExpectHandled(ft)
+	if len(ft.failures) != 0 || ft.helperCalls == 0 {
+		t.Errorf("Expected handled block to pass via Helper, got %v", ft.failures)
+	}
+
+	ft = &fakeT{}
+	Try(func() { panic("x") }).ExpectHandled(ft)
+	if len(ft.failures) != 1 || !strings.Contains(ft.failures[0], "string is unhandled") {
+		t.Errorf("Expected unhandled failure, got %v", ft.failures)
+	}
+
+	ft = &fakeT{}
+	Try(func() {}).ExpectHandled(ft)
+	if len(ft.failures) != 1 || !strings.Contains(ft.failures[0], "nothing panicked") {
+		t.Errorf("Expected clean-block failure, got %v", ft.failures)
+	}
+}
+
+func TestExpectClean(t *testing.T) {
+	ft := &fakeT{}
+	Try(func() {}).ExpectClean(ft)
+	if len(ft.failures) != 0 {
+		t.Errorf("Expected clean block to pass, got %v", ft.failures)
+	}
+
+	ft = &fakeT{}
+	Catch[string](Try(func() { panic("boom") }), func(string) {}).ExpectClean(ft)
+	if len(ft.failures) != 1 || !strings.Contains(ft.failures[0], "handled string: boom") {
+		t.Errorf("Expected failure naming the handled panic, got %v", ft.failures)
+	}
+}
@@ -0,0 +1,60 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ============================================
+// CauseChain - Printing wrapped error chains
+// ============================================
+
+// causeEntry is a single message in a cause chain together with its nesting depth.
+type causeEntry struct {
+	depth int
+	msg   string
+}
+
+// collectCauses walks v depth-first and appends one entry per error in the chain.
+func collectCauses(v interface{}, depth int, entries []causeEntry) []causeEntry {
+	if v == nil || depth > maxChainDepth {
+		return entries
+	}
+
+	entries = append(entries, causeEntry{depth: depth, msg: Describe(v)})
+
+	for _, inner := range unwrapOnce(v) {
+		entries = collectCauses(inner, depth+1, entries)
+	}
+	return entries
+}
+
+// CauseChain returns the messages of err and of each error it wraps, outermost first.
+// Each value is formatted with Describe.
+// Multi-error wrappers (Unwrap() []error) are walked depth-first.
+// Returns nil if err is nil.
+func CauseChain(err interface{}) []string {
+	entries := collectCauses(err, 0, nil)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	chain := make([]string, len(entries))
+	for i, e := range entries {
+		chain[i] = e.msg
+	}
+	return chain
+}
+
+// PrintCauseChain writes the cause chain of err to w, one message per line,
+// indenting each wrapped cause by two spaces per level.
+func PrintCauseChain(w io.Writer, err interface{}) {
+	for _, e := range collectCauses(err, 0, nil) {
+		prefix := ""
+		if e.depth > 0 {
+			prefix = strings.Repeat("  ", e.depth) + "caused by: "
+		}
+		fmt.Fprintf(w, "%s%s\n", prefix, e.msg)
+	}
+}
@@ -0,0 +1,76 @@
+package gotrycatch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// CauseChain 测试
+// ============================================
+
+func TestCauseChain_DatabaseError(t *testing.T) {
+	root := errors.New("connection refused")
+	wrapped := fmt.Errorf("dial tcp: %w", root)
+	dbErr := trycatcherrors.NewDatabaseError("SELECT", "users", wrapped)
+
+	chain := CauseChain(dbErr)
+
+	if len(chain) != 3 {
+		t.Fatalf("Expected 3 entries, got %d: %v", len(chain), chain)
+	}
+	if !strings.HasPrefix(chain[0], "database error during SELECT on table 'users'") {
+		t.Errorf("Expected DatabaseError first, got %s", chain[0])
+	}
+	if chain[1] != "dial tcp: connection refused" {
+		t.Errorf("Expected wrapped cause second, got %s", chain[1])
+	}
+	if chain[2] != "connection refused" {
+		t.Errorf("Expected root cause last, got %s", chain[2])
+	}
+}
+
+func TestCauseChain_NonError(t *testing.T) {
+	chain := CauseChain(42)
+	if len(chain) != 1 || chain[0] != "42" {
+		t.Errorf("Expected [42], got %v", chain)
+	}
+
+	if CauseChain(nil) != nil {
+		t.Error("Expected nil chain for nil error")
+	}
+}
+
+func TestCauseChain_Joined(t *testing.T) {
+	chain := CauseChain(errors.Join(errors.New("a"), errors.New("b")))
+	if len(chain) != 3 || chain[1] != "a" || chain[2] != "b" {
+		t.Errorf("Expected joined branches after the join message, got %v", chain)
+	}
+}
+
+func TestPrintCauseChain(t *testing.T) {
+	root := errors.New("disk full")
+	dbErr := trycatcherrors.NewDatabaseError("INSERT", "logs", fmt.Errorf("write failed: %w", root))
+
+	var buf bytes.Buffer
+	PrintCauseChain(&buf, dbErr)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "database error during INSERT") {
+		t.Errorf("Unexpected first line: %s", lines[0])
+	}
+	if lines[1] != "  caused by: write failed: disk full" {
+		t.Errorf("Unexpected second line: %q", lines[1])
+	}
+	if lines[2] != "    caused by: disk full" {
+		t.Errorf("Unexpected third line: %q", lines[2])
+	}
+}
@@ -0,0 +1,37 @@
+package gotrycatch
+
+// ============================================
+// Channel helpers - Panics in concurrent code
+// ============================================
+
+// DrainPanics reads panic values from ch until it is closed and groups them by TypeName.
+// Nil values are skipped. It blocks until ch is closed.
+func DrainPanics(ch <-chan interface{}) map[string][]interface{} {
+	groups := make(map[string][]interface{})
+	for v := range ch {
+		if v == nil {
+			continue
+		}
+		name := TypeName(v)
+		groups[name] = append(groups[name], v)
+	}
+	return groups
+}
+
+// TryToChannel runs fn and, if it panics, sends the panic value to out.
+// The send never blocks: if out is full (or nil) the value is dropped.
+// Returns true only if a panic occurred and its value was delivered.
+func TryToChannel(fn func(), out chan<- interface{}) bool {
+	tb := Try(fn)
+	if !tb.HasError() {
+		return false
+	}
+
+	select {
+	case out <- tb.err:
+		return true
+	default:
+		debugLog("TryToChannel: channel full, dropping panic of type %T", tb.err)
+		return false
+	}
+}
@@ -0,0 +1,120 @@
+package gotrycatch
+
+import (
+	"testing"
+	"time"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// DrainPanics 测试
+// ============================================
+
+func TestDrainPanics_GroupsByType(t *testing.T) {
+	ch := make(chan interface{}, 10)
+	ch <- "first"
+	ch <- trycatcherrors.NewValidationError("a", "m", 1)
+	ch <- 42
+	ch <- "second"
+	ch <- nil
+	ch <- trycatcherrors.NewValidationError("b", "m", 2)
+	close(ch)
+
+	groups := DrainPanics(ch)
+
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d: %v", len(groups), groups)
+	}
+	if strs := groups["string"]; len(strs) != 2 || strs[0] != "first" || strs[1] != "second" {
+		t.Errorf("Expected string group in arrival order, got %v", strs)
+	}
+	if len(groups["errors.ValidationError"]) != 2 {
+		t.Errorf("Expected 2 ValidationErrors, got %d", len(groups["errors.ValidationError"]))
+	}
+	if ints := groups["int"]; len(ints) != 1 || ints[0] != 42 {
+		t.Errorf("Expected int group [42], got %v", ints)
+	}
+}
+
+func TestDrainPanics_FromWorkers(t *testing.T) {
+	ch := make(chan interface{})
+	done := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		go func(i int) {
+			defer func() { done <- struct{}{} }()
+			Try(func() { panic(i) }).CatchAny(func(err interface{}) { ch <- err })
+		}(i)
+	}
+	go func() {
+		for i := 0; i < 3; i++ {
+			<-done
+		}
+		close(ch)
+	}()
+
+	groups := DrainPanics(ch)
+	if len(groups["int"]) != 3 {
+		t.Errorf("Expected 3 int panics, got %v", groups)
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	if TypeName(nil) != "" {
+		t.Errorf("Expected empty name for nil, got %q", TypeName(nil))
+	}
+	if TypeName("x") != "string" {
+		t.Errorf("Expected 'string', got %q", TypeName("x"))
+	}
+	if TypeName(trycatcherrors.NewAuthError("o", "u", "r")) != "errors.AuthError" {
+		t.Errorf("Expected 'errors.AuthError', got %q", TypeName(trycatcherrors.NewAuthError("o", "u", "r")))
+	}
+}
+
+// ============================================
+// TryToChannel 测试
+// ============================================
+
+func TestTryToChannel_Delivers(t *testing.T) {
+	out := make(chan interface{}, 1)
+
+	delivered := TryToChannel(func() { panic("worker failed") }, out)
+
+	if !delivered {
+		t.Fatal("Expected panic value to be delivered")
+	}
+	if v := <-out; v != "worker failed" {
+		t.Errorf("Expected 'worker failed', got %v", v)
+	}
+}
+
+func TestTryToChannel_NoPanic(t *testing.T) {
+	out := make(chan interface{}, 1)
+
+	if TryToChannel(func() {}, out) {
+		t.Error("Expected false when nothing panicked")
+	}
+	if len(out) != 0 {
+		t.Error("Expected nothing sent for a clean run")
+	}
+}
+
+func TestTryToChannel_FullChannelDoesNotBlock(t *testing.T) {
+	out := make(chan interface{}, 1)
+	out <- "occupied"
+
+	done := make(chan bool)
+	go func() { done <- TryToChannel(func() { panic("dropped") }, out) }()
+
+	select {
+	case delivered := <-done:
+		if delivered {
+			t.Error("Expected false when the channel is full")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("TryToChannel blocked on a full channel")
+	}
+	if v := <-out; v != "occupied" {
+		t.Errorf("Expected original value to remain, got %v", v)
+	}
+}
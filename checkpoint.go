@@ -0,0 +1,79 @@
+package gotrycatch
+
+import "fmt"
+
+// ============================================
+// TryCheckpoint - Resumable multi-step workflows
+// ============================================
+
+// Step is a named unit of work in a TryCheckpoint workflow.
+// Steps should be idempotent, since a resumed workflow re-runs the failed step.
+type Step struct {
+	Name string
+	Fn   func()
+}
+
+// checkpoint records the steps of a TryCheckpoint run and the one that failed.
+type checkpoint struct {
+	steps  []Step
+	failed int // index of the failed step
+}
+
+// TryCheckpoint runs steps in order, stopping at the first one that panics.
+// The panic is captured in the returned TryBlock along with the failed step, whose
+// name is available via FailedStepName and index via FailedStep. Call Resume on the
+// block to continue the workflow without re-running the steps that succeeded.
+// Steps with a nil Fn are skipped. If every step succeeds, a clean TryBlock is returned.
+func TryCheckpoint(steps []Step) *TryBlock {
+	return runCheckpoint(steps, 0)
+}
+
+func runCheckpoint(steps []Step, start int) *TryBlock {
+	for i := start; i < len(steps); i++ {
+		if steps[i].Fn == nil {
+			continue
+		}
+		tb := Try(steps[i].Fn)
+		if tb.HasError() {
+			tb.failedStep = i + 1
+			tb.checkpoint = &checkpoint{steps: steps, failed: i}
+			debugLog("TryCheckpoint: step %q panicked with %T", steps[i].Name, tb.err)
+			return tb
+		}
+	}
+	return &TryBlock{}
+}
+
+// FailedStepName returns the name of the TryCheckpoint step that panicked.
+// Returns "" if no step failed, the block was not created by TryCheckpoint, or the TryBlock is nil.
+func (tb *TryBlock) FailedStepName() string {
+	if tb == nil || tb.checkpoint == nil {
+		return ""
+	}
+	return tb.checkpoint.steps[tb.checkpoint.failed].Name
+}
+
+// Resume continues a failed TryCheckpoint workflow from the step named from, skipping
+// the steps before it. An empty from resumes at the step that failed. The result is a
+// new TryBlock for the resumed run, which can itself be resumed.
+// If the block has no checkpoint, it is returned unchanged; if no step is named from,
+// the returned block holds an error describing the unknown step.
+func (tb *TryBlock) Resume(from string) *TryBlock {
+	if tb == nil || tb.checkpoint == nil {
+		debugLog("Resume: block has no checkpoint, returning it unchanged")
+		return tb
+	}
+
+	cp := tb.checkpoint
+	if from == "" {
+		debugLog("Resume: resuming at failed step %q", cp.steps[cp.failed].Name)
+		return runCheckpoint(cp.steps, cp.failed)
+	}
+	for i, step := range cp.steps {
+		if step.Name == from {
+			debugLog("Resume: resuming at step %q", from)
+			return runCheckpoint(cp.steps, i)
+		}
+	}
+	return &TryBlock{err: fmt.Errorf("gotrycatch: no checkpoint step named %q", from)}
+}
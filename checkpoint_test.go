@@ -0,0 +1,82 @@
+package gotrycatch
+
+import (
+	"strings"
+	"testing"
+)
+
+// ============================================
+// TryCheckpoint 测试
+// ============================================
+
+func TestTryCheckpoint_ResumeSkipsCompletedSteps(t *testing.T) {
+	runs := map[string]int{}
+	fail := true
+	steps := []Step{
+		{Name: "fetch", Fn: func() { runs["fetch"]++ }},
+		{Name: "transform", Fn: func() {
+			runs["transform"]++
+			if fail {
+				panic("transform failed")
+			}
+		}},
+		{Name: "store", Fn: func() { runs["store"]++ }},
+	}
+
+	tb := TryCheckpoint(steps)
+	if tb.GetError() != "transform failed" {
+		t.Fatalf("Expected transform panic, got %v", tb.GetError())
+	}
+	if tb.FailedStepName() != "transform" || tb.FailedStep() != 1 {
+		t.Errorf("Expected failed step transform at index 1, got %q at %d", tb.FailedStepName(), tb.FailedStep())
+	}
+
+	fail = false
+	resumed := tb.Resume("")
+	if resumed.HasError() {
+		t.Fatalf("Expected resumed run to succeed, got %v", resumed.GetError())
+	}
+	if runs["fetch"] != 1 || runs["transform"] != 2 || runs["store"] != 1 {
+		t.Errorf("Expected fetch once, transform twice, store once, got %v", runs)
+	}
+}
+
+func TestTryCheckpoint_ResumeFromNamedStep(t *testing.T) {
+	var order []string
+	steps := []Step{
+		{Name: "a", Fn: func() { order = append(order, "a") }},
+		{Name: "b", Fn: func() { order = append(order, "b") }},
+		{Name: "c", Fn: func() { order = append(order, "c"); panic("c failed") }},
+	}
+
+	tb := TryCheckpoint(steps)
+	order = nil
+	resumed := tb.Resume("b")
+
+	if strings.Join(order, ",") != "b,c" {
+		t.Errorf("Expected resume to run b,c, got %v", order)
+	}
+	if resumed.FailedStepName() != "c" {
+		t.Errorf("Expected resumed run to fail at c, got %q", resumed.FailedStepName())
+	}
+}
+
+func TestTryCheckpoint_ResumeUnknownStep(t *testing.T) {
+	tb := TryCheckpoint([]Step{{Name: "only", Fn: func() { panic("boom") }}})
+
+	resumed := tb.Resume("missing")
+	if err, ok := resumed.GetError().(error); !ok || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("Expected unknown step error, got %v", resumed.GetError())
+	}
+}
+
+func TestTryCheckpoint_Success(t *testing.T) {
+	tb := TryCheckpoint([]Step{{Name: "a", Fn: func() {}}, {Name: "nil"}})
+
+	if tb.HasError() || tb.FailedStepName() != "" || tb.FailedStep() != -1 {
+		t.Errorf("Expected clean block, got %v", tb)
+	}
+	if tb.Resume("a") != tb {
+		t.Error("Expected Resume on a clean block to return it unchanged")
+	}
+}
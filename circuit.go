@@ -0,0 +1,105 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ============================================
+// CircuitBreaker - Panic budget for flaky dependencies
+// ============================================
+
+// CircuitOpenError is the error captured by CircuitBreaker.Do when the breaker is open
+// and the function was not run.
+type CircuitOpenError struct {
+	OpenedAt time.Time // When the breaker tripped
+	RetryAt  time.Time // When the breaker will allow calls again
+}
+
+func (e CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open since %s, retry at %s", e.OpenedAt.Format(time.RFC3339), e.RetryAt.Format(time.RFC3339))
+}
+
+// CircuitBreaker stops running a function after too many panics in a short window.
+// Once threshold panics occur within window, the breaker opens and Do fails fast with a
+// CircuitOpenError without running the function. After cooldown the breaker closes again.
+// A CircuitBreaker is safe for concurrent use.
+type CircuitBreaker struct {
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+	now       func() time.Time // injectable clock for tests
+
+	mu       sync.Mutex
+	failures []time.Time
+	open     bool
+	openedAt time.Time
+}
+
+// NewCircuitBreaker creates a breaker that trips after threshold panics within window
+// and stays open for cooldown. A threshold below 1 is treated as 1.
+func NewCircuitBreaker(threshold int, window, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		window:    window,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Do runs fn under Try unless the breaker is open.
+// When open, fn is not run and the returned TryBlock holds a CircuitOpenError.
+func (cb *CircuitBreaker) Do(fn func()) *TryBlock {
+	cb.mu.Lock()
+	now := cb.now()
+	if cb.open {
+		if now.Sub(cb.openedAt) < cb.cooldown {
+			err := CircuitOpenError{OpenedAt: cb.openedAt, RetryAt: cb.openedAt.Add(cb.cooldown)}
+			cb.mu.Unlock()
+			debugLog("CircuitBreaker: open, failing fast")
+			return &TryBlock{err: err}
+		}
+		debugLog("CircuitBreaker: cooldown elapsed, closing")
+		cb.open = false
+		cb.failures = nil
+	}
+	cb.mu.Unlock()
+
+	tb := Try(fn)
+	if tb.HasError() {
+		cb.recordFailure()
+	}
+	return tb
+}
+
+// IsOpen reports whether the breaker is currently open.
+func (cb *CircuitBreaker) IsOpen() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.open && cb.now().Sub(cb.openedAt) < cb.cooldown
+}
+
+// recordFailure records a panic and trips the breaker if the threshold is reached.
+func (cb *CircuitBreaker) recordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+	recent := cb.failures[:0]
+	for _, at := range cb.failures {
+		if now.Sub(at) < cb.window {
+			recent = append(recent, at)
+		}
+	}
+	cb.failures = append(recent, now)
+
+	if !cb.open && len(cb.failures) >= cb.threshold {
+		debugLog("CircuitBreaker: %d panics within %v, opening", len(cb.failures), cb.window)
+		cb.open = true
+		cb.openedAt = now
+	}
+}
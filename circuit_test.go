@@ -0,0 +1,105 @@
+package gotrycatch
+
+import (
+	"testing"
+	"time"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// CircuitBreaker 测试
+// ============================================
+
+// fakeClock is a manually advanced clock for time-dependent tests.
+type fakeClock struct {
+	t time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time          { return c.t }
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestCircuitBreaker_TripsAndRecovers(t *testing.T) {
+	clock := newFakeClock()
+	cb := NewCircuitBreaker(3, time.Minute, 30*time.Second)
+	cb.now = clock.Now
+
+	var calls int
+	flaky := func() {
+		calls++
+		panic(trycatcherrors.NewNetworkError("https://api.example.com", 503))
+	}
+
+	for i := 0; i < 3; i++ {
+		tb := cb.Do(flaky)
+		if _, ok := tb.GetError().(trycatcherrors.NetworkError); !ok {
+			t.Fatalf("Call %d: expected NetworkError, got %T", i, tb.GetError())
+		}
+		clock.Advance(time.Second)
+	}
+	if !cb.IsOpen() {
+		t.Fatal("Expected breaker to be open after 3 panics")
+	}
+
+	// Fast fail without running the function
+	tb := cb.Do(flaky)
+	if _, ok := tb.GetError().(CircuitOpenError); !ok {
+		t.Errorf("Expected CircuitOpenError, got %T", tb.GetError())
+	}
+	if calls != 3 {
+		t.Errorf("Expected function not to run while open, got %d calls", calls)
+	}
+
+	// Recovery after cooldown
+	clock.Advance(30 * time.Second)
+	if cb.IsOpen() {
+		t.Error("Expected breaker to close after cooldown")
+	}
+	var ran bool
+	tb = cb.Do(func() { ran = true })
+	if !ran || tb.HasError() {
+		t.Errorf("Expected function to run after cooldown, ran=%v err=%v", ran, tb.GetError())
+	}
+}
+
+func TestCircuitBreaker_WindowExpiry(t *testing.T) {
+	clock := newFakeClock()
+	cb := NewCircuitBreaker(2, 10*time.Second, time.Minute)
+	cb.now = clock.Now
+
+	cb.Do(func() { panic("first") })
+	clock.Advance(11 * time.Second)
+	cb.Do(func() { panic("second") })
+
+	if cb.IsOpen() {
+		t.Error("Expected breaker to stay closed when panics fall outside the window")
+	}
+
+	cb.Do(func() { panic("third") })
+	if !cb.IsOpen() {
+		t.Error("Expected breaker to open with 2 panics inside the window")
+	}
+}
+
+func TestCircuitBreaker_SuccessDoesNotCount(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Minute, time.Minute)
+	for i := 0; i < 5; i++ {
+		cb.Do(func() {})
+	}
+	if cb.IsOpen() {
+		t.Error("Expected successful calls not to trip the breaker")
+	}
+}
+
+func TestCircuitOpenError_Error(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := CircuitOpenError{OpenedAt: at, RetryAt: at.Add(time.Minute)}
+	expected := "circuit breaker open since 2024-01-01T00:00:00Z, retry at 2024-01-01T00:01:00Z"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
@@ -0,0 +1,57 @@
+package gotrycatch
+
+import (
+	"fmt"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// CodeOf / CatchCodeRange - Error code families
+// ============================================
+
+// Coder can be implemented by custom error types that carry a numeric error code.
+type Coder interface {
+	ErrorCode() int
+}
+
+// CodeOf returns the numeric error code of err. Values implementing Coder report their
+// ErrorCode, and ValidationError (in value or pointer form) reports its Code field.
+// The second result is false for values without a code.
+func CodeOf(err interface{}) (int, bool) {
+	if c, ok := err.(Coder); ok {
+		return c.ErrorCode(), true
+	}
+	if ve, ok := ValueOf[trycatcherrors.ValidationError](err); ok {
+		return ve.Code, true
+	}
+	return 0, false
+}
+
+// CatchCodeRange handles panics whose CodeOf falls within [min, max], so handlers can
+// target a family of codes such as 1000-1999 for validation failures.
+// Values without a code never match.
+// Returns the same TryBlock to allow chaining multiple Catch calls.
+func CatchCodeRange(tb *TryBlock, min, max int, handler func(interface{})) *TryBlock {
+	if tb == nil {
+		debugLog("CatchCodeRange: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchCodeRange: handler is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	noteCatchTarget(tb, "CatchCodeRange", fmt.Sprintf("%d-%d", min, max))
+	if tb.err != nil && !tb.handled {
+		if code, ok := CodeOf(tb.err); ok && code >= min && code <= max {
+			debugLog("CatchCodeRange: code %d in [%d, %d], calling handler", code, min, max)
+			handler(tb.err)
+			tb.handled = true
+		} else {
+			debugLog("CatchCodeRange: %T has no code in [%d, %d]", tb.err, min, max)
+		}
+	}
+	return tb
+}
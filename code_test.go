@@ -0,0 +1,71 @@
+package gotrycatch
+
+import (
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// codedError is a custom error type carrying a code through the Coder interface.
+type codedError struct{ code int }
+
+func (e codedError) Error() string  { return "coded" }
+func (e codedError) ErrorCode() int { return e.code }
+
+// ============================================
+// CodeOf 测试
+// ============================================
+
+func TestCodeOf(t *testing.T) {
+	ve := trycatcherrors.NewValidationError("f", "m", 1001)
+
+	tests := []struct {
+		value interface{}
+		code  int
+		ok    bool
+	}{
+		{ve, 1001, true},
+		{&ve, 1001, true},
+		{codedError{code: 2500}, 2500, true},
+		{"boom", 0, false},
+		{trycatcherrors.NewNetworkError("u", 503), 0, false},
+	}
+
+	for _, tt := range tests {
+		code, ok := CodeOf(tt.value)
+		if code != tt.code || ok != tt.ok {
+			t.Errorf("CodeOf(%T) = %d, %v; want %d, %v", tt.value, code, ok, tt.code, tt.ok)
+		}
+	}
+}
+
+// ============================================
+// CatchCodeRange 测试
+// ============================================
+
+func TestCatchCodeRange(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{trycatcherrors.NewValidationError("f", "m", 1000), "validation"},
+		{trycatcherrors.NewValidationError("f", "m", 1999), "validation"},
+		{codedError{code: 2001}, "business"},
+		{codedError{code: 3000}, ""},
+		{"no code", ""},
+	}
+
+	for _, tt := range tests {
+		var fired string
+		tb := Try(func() { panic(tt.value) })
+		tb = CatchCodeRange(tb, 1000, 1999, func(interface{}) { fired = "validation" })
+		tb = CatchCodeRange(tb, 2000, 2999, func(interface{}) { fired = "business" })
+
+		if fired != tt.want {
+			t.Errorf("Expected %q for %v, got %q", tt.want, tt.value, fired)
+		}
+		if tb.IsHandled() != (tt.want != "") {
+			t.Errorf("Expected handled=%v for %v", tt.want != "", tt.value)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package gotrycatch
+
+import "sync"
+
+// ============================================
+// Collector - Panics accumulated across a loop
+// ============================================
+
+// Collector accumulates the panics of many protected calls, typically one per loop
+// iteration, so a batch can finish and report its failures at the end.
+// The zero value is ready to use. A Collector is not safe for concurrent use;
+// use Group for goroutines.
+//
+//	var c gotrycatch.Collector
+//	for _, row := range rows {
+//		c.Do(func() { importRow(row) })
+//	}
+//	for _, e := range c.Deduped() {
+//		log.Printf("%d× %v", e.Count, e.Err)
+//	}
+type Collector struct {
+	errs []interface{}
+}
+
+// Do runs fn and records its panic, if any. It reports whether fn panicked.
+// Like TryFast it does not allocate a TryBlock, so a loop of calls only allocates
+// when the collector's buffer grows.
+func (c *Collector) Do(fn func()) bool {
+	panicked, value := TryFast(fn)
+	if !panicked {
+		return false
+	}
+	debugLog("Collector: recorded panic of type %T", value)
+	c.errs = append(c.errs, value)
+	return true
+}
+
+// Add records err as if it had been recovered from a panic. Nil values are ignored.
+func (c *Collector) Add(err interface{}) {
+	if err == nil {
+		return
+	}
+	c.errs = append(c.errs, err)
+}
+
+// Len returns the number of recorded errors.
+func (c *Collector) Len() int {
+	return len(c.errs)
+}
+
+// Errors returns a copy of the recorded errors, in the order they occurred.
+func (c *Collector) Errors() []interface{} {
+	return append([]interface{}(nil), c.errs...)
+}
+
+// Into appends the recorded errors to dst and returns the extended slice, letting
+// callers reuse a buffer across batches instead of allocating a copy with Errors:
+//
+//	buf = c.Into(buf[:0])
+func (c *Collector) Into(dst []interface{}) []interface{} {
+	return append(dst, c.errs...)
+}
+
+// Reset discards the recorded errors but keeps the buffer, so the collector can be
+// reused for the next batch without growing it again.
+func (c *Collector) Reset() {
+	clear(c.errs)
+	c.errs = c.errs[:0]
+}
+
+// maxPooledErrors bounds the buffer size of collectors returned to the pool, so one
+// unusually large batch does not pin its memory for the life of the process.
+const maxPooledErrors = 1 << 16
+
+var collectorPool = sync.Pool{
+	New: func() interface{} { return new(Collector) },
+}
+
+// AcquireCollector returns an empty Collector from a pool, whose buffer has typically
+// been grown by earlier batches, for tight loops where buffer growth shows up in
+// profiles. Pair it with Release:
+//
+//	c := gotrycatch.AcquireCollector()
+//	defer c.Release()
+//
+// The reuse contract: after Release the collector belongs to the pool and must not be
+// used, and slices obtained from it must not alias its buffer. Errors and Deduped return
+// fresh slices and Into copies into the caller's buffer, so their results stay valid.
+func AcquireCollector() *Collector {
+	return collectorPool.Get().(*Collector)
+}
+
+// Release resets the collector and returns it to the pool used by AcquireCollector.
+// The collector must not be used afterwards. Collectors with very large buffers are
+// dropped instead of pooled.
+func (c *Collector) Release() {
+	if cap(c.errs) > maxPooledErrors {
+		return
+	}
+	c.Reset()
+	collectorPool.Put(c)
+}
+
+// CountedError is one distinct error reported by Collector.Deduped.
+type CountedError struct {
+	Err         interface{} // First recorded occurrence
+	Fingerprint string      // Fingerprint shared by all occurrences
+	Count       int         // Number of occurrences
+}
+
+// Deduped compacts the recorded errors by Fingerprint, returning each distinct error
+// once with its number of occurrences, in order of first occurrence. It keeps summaries
+// of loops that fail the same way many times concise.
+func (c *Collector) Deduped() []CountedError {
+	var result []CountedError
+	index := make(map[string]int)
+	for _, err := range c.errs {
+		fp := Fingerprint(err)
+		if i, ok := index[fp]; ok {
+			result[i].Count++
+			continue
+		}
+		index[fp] = len(result)
+		result = append(result, CountedError{Err: err, Fingerprint: fp, Count: 1})
+	}
+	return result
+}
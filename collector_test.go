@@ -0,0 +1,136 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Collector 测试
+// ============================================
+
+func TestCollector_Do(t *testing.T) {
+	var c Collector
+	for i := 0; i < 5; i++ {
+		panicked := c.Do(func() {
+			if i%2 == 0 {
+				panic(i)
+			}
+		})
+		if panicked != (i%2 == 0) {
+			t.Errorf("Iteration %d: expected panicked=%v, got %v", i, i%2 == 0, panicked)
+		}
+	}
+	c.Add(nil)
+
+	errs := c.Errors()
+	if c.Len() != 3 || len(errs) != 3 || errs[0] != 0 || errs[2] != 4 {
+		t.Errorf("Expected panics [0 2 4], got %v", errs)
+	}
+}
+
+func TestCollector_Deduped(t *testing.T) {
+	var c Collector
+	for i := 0; i < 4; i++ {
+		c.Do(func() {
+			panic(trycatcherrors.NewValidationError("email", fmt.Sprintf("bad value %d", i), 1001))
+		})
+	}
+	c.Add("timeout")
+	c.Add(trycatcherrors.NewValidationError("name", "required", 1002))
+	c.Add("timeout")
+
+	deduped := c.Deduped()
+	if len(deduped) != 3 {
+		t.Fatalf("Expected 3 distinct errors, got %d: %v", len(deduped), deduped)
+	}
+
+	first, ok := deduped[0].Err.(trycatcherrors.ValidationError)
+	if !ok || first.Message != "bad value 0" || deduped[0].Count != 4 {
+		t.Errorf("Expected first email error counted 4 times, got %v", deduped[0])
+	}
+	if deduped[1].Err != "timeout" || deduped[1].Count != 2 {
+		t.Errorf("Expected timeout counted twice, got %v", deduped[1])
+	}
+	if deduped[2].Count != 1 || deduped[2].Fingerprint != Fingerprint(deduped[2].Err) {
+		t.Errorf("Expected name error once with its fingerprint, got %v", deduped[2])
+	}
+}
+
+func TestCollector_Empty(t *testing.T) {
+	var c Collector
+	if c.Deduped() != nil || c.Errors() != nil || c.Len() != 0 {
+		t.Error("Expected an empty collector to report nothing")
+	}
+}
+
+func TestCollector_Into(t *testing.T) {
+	var c Collector
+	c.Add("a")
+	c.Add("b")
+
+	buf := make([]interface{}, 0, 8)
+	buf = c.Into(buf[:0])
+	if len(buf) != 2 || buf[0] != "a" || buf[1] != "b" {
+		t.Errorf("Expected [a b], got %v", buf)
+	}
+
+	c.Reset()
+	c.Add("c")
+	buf = c.Into(buf[:0])
+	if c.Len() != 1 || len(buf) != 1 || buf[0] != "c" {
+		t.Errorf("Expected reset collector to hold only c, got %v", buf)
+	}
+}
+
+func TestCollector_PooledLargeLoop(t *testing.T) {
+	const n = 10000
+	buf := make([]interface{}, 0, n)
+
+	for batch := 0; batch < 3; batch++ {
+		c := AcquireCollector()
+		if c.Len() != 0 {
+			t.Fatalf("Expected an empty collector from the pool, got %d errors", c.Len())
+		}
+		for i := 0; i < n; i++ {
+			c.Do(func() {
+				if i%2 == 0 {
+					panic("even")
+				}
+			})
+		}
+		buf = c.Into(buf[:0])
+		c.Release()
+
+		if len(buf) != n/2 || buf[0] != "even" {
+			t.Errorf("Batch %d: expected %d collected panics, got %d", batch, n/2, len(buf))
+		}
+	}
+}
+
+// Pool reuse is measured by BenchmarkCollector_Pooled rather than asserted with
+// testing.AllocsPerRun: sync.Pool may drop any Put (always possible under the race
+// detector, and on GC otherwise), so a zero-allocation assertion would be flaky.
+
+func BenchmarkCollector_Unpooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var c Collector
+		for j := 0; j < 1000; j++ {
+			c.Add(true)
+		}
+	}
+}
+
+func BenchmarkCollector_Pooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c := AcquireCollector()
+		for j := 0; j < 1000; j++ {
+			c.Add(true)
+		}
+		c.Release()
+	}
+}
@@ -0,0 +1,57 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================
+// Combine - Fan-in of several TryBlocks
+// ============================================
+
+// MultiError aggregates several panic values, e.g. the failures of parallel tasks.
+// Values keep their original types; use Unwrap or CatchChain to match them.
+type MultiError struct {
+	Errors []interface{}
+}
+
+func (e MultiError) Error() string {
+	if len(e.Errors) == 1 {
+		return fmt.Sprint(e.Errors[0])
+	}
+	parts := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		parts[i] = fmt.Sprint(err)
+	}
+	return fmt.Sprintf("%d errors: %s", len(e.Errors), strings.Join(parts, "; "))
+}
+
+// Unwrap returns the aggregated values that implement error, so errors.Is/As and
+// CatchChain can see through the MultiError. Non-error panic values are skipped.
+func (e MultiError) Unwrap() []error {
+	var errs []error
+	for _, v := range e.Errors {
+		if err, ok := v.(error); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Combine merges several TryBlocks into one. The result is clean if every input is
+// clean or handled; otherwise it holds a MultiError with the unhandled errors in
+// input order. Nil blocks are ignored.
+func Combine(blocks ...*TryBlock) *TryBlock {
+	var errs []interface{}
+	for _, tb := range blocks {
+		if tb != nil && tb.err != nil && !tb.handled {
+			errs = append(errs, tb.err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return &TryBlock{}
+	}
+	debugLog("Combine: %d of %d block(s) have unhandled errors", len(errs), len(blocks))
+	return &TryBlock{err: MultiError{Errors: errs}}
+}
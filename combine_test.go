@@ -0,0 +1,67 @@
+package gotrycatch
+
+import (
+	"errors"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Combine 测试
+// ============================================
+
+func TestCombine_AllClean(t *testing.T) {
+	handled := Catch[string](Try(func() { panic("handled") }), func(string) {})
+
+	tb := Combine(Try(func() {}), handled, nil)
+
+	if tb.HasError() {
+		t.Errorf("Expected clean block, got %v", tb.GetError())
+	}
+}
+
+func TestCombine_Aggregates(t *testing.T) {
+	dbErr := trycatcherrors.NewDatabaseError("SELECT", "users", nil)
+
+	tb := Combine(
+		Try(func() {}),
+		Try(func() { panic(dbErr) }),
+		Catch[string](Try(func() { panic("handled") }), func(string) {}),
+		Try(func() { panic(42) }),
+	)
+
+	multi, ok := tb.GetError().(MultiError)
+	if !ok {
+		t.Fatalf("Expected MultiError, got %T", tb.GetError())
+	}
+	if len(multi.Errors) != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %v", len(multi.Errors), multi.Errors)
+	}
+	if multi.Errors[1] != 42 {
+		t.Errorf("Expected errors in input order, got %v", multi.Errors)
+	}
+	if tb.IsHandled() {
+		t.Error("Expected combined block to be unhandled")
+	}
+
+	var target trycatcherrors.DatabaseError
+	if !errors.As(multi, &target) {
+		t.Error("Expected errors.As to find DatabaseError through MultiError")
+	}
+
+	var caught bool
+	CatchChain[trycatcherrors.DatabaseError](tb, func(trycatcherrors.DatabaseError) { caught = true })
+	if !caught {
+		t.Error("Expected CatchChain to match inside MultiError")
+	}
+}
+
+func TestMultiError_Error(t *testing.T) {
+	if got := (MultiError{Errors: []interface{}{"a"}}).Error(); got != "a" {
+		t.Errorf("Expected 'a', got %q", got)
+	}
+	if got := (MultiError{Errors: []interface{}{"a", 2}}).Error(); got != "2 errors: a; 2" {
+		t.Errorf("Expected '2 errors: a; 2', got %q", got)
+	}
+}
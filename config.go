@@ -0,0 +1,90 @@
+package gotrycatch
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// ConfigureFromEnv - Environment-based configuration
+// ============================================
+
+// envBoolFlags lists the boolean package flags that ConfigureFromEnv can set.
+var envBoolFlags = []struct {
+	name string
+	set  func(bool)
+}{
+	{"GOTRYCATCH_DEBUG", SetDebug},
+	{"GOTRYCATCH_CAPTURE_STACK", func(v bool) { CaptureStack = v }},
+	{"GOTRYCATCH_TRACK_IN_FLIGHT", func(v bool) { TrackInFlight = v }},
+	{"GOTRYCATCH_DEBUG_ORDERING", func(v bool) { DebugOrdering = v }},
+	{"GOTRYCATCH_CAPTURE_LOCATION", func(v bool) { trycatcherrors.CaptureLocation = v }},
+	{"GOTRYCATCH_RECORD_HISTORY", func(v bool) { RecordHistory = v }},
+}
+
+// ConfigureFromEnv sets package flags from environment variables, so operators can
+// toggle behavior without code changes:
+//
+//	GOTRYCATCH_DEBUG               SetDebug
+//	GOTRYCATCH_CAPTURE_STACK       CaptureStack
+//	GOTRYCATCH_TRACK_IN_FLIGHT     TrackInFlight
+//	GOTRYCATCH_DEBUG_ORDERING      DebugOrdering
+//	GOTRYCATCH_CAPTURE_LOCATION    errors.CaptureLocation
+//	GOTRYCATCH_RECORD_HISTORY      RecordHistory
+//	GOTRYCATCH_RETHROW_AT_OR_ABOVE RethrowAtOrAbove (info, warning, error, critical)
+//
+// Boolean values accept anything strconv.ParseBool does. Unset variables leave their
+// flag unchanged. If any value is malformed, no flag is changed and the returned error
+// describes every invalid variable.
+func ConfigureFromEnv() error {
+	var apply []func()
+	var errs []error
+
+	for _, flag := range envBoolFlags {
+		raw, ok := os.LookupEnv(flag.name)
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid boolean %q", flag.name, raw))
+			continue
+		}
+		set := flag.set
+		apply = append(apply, func() { set(v) })
+	}
+
+	if raw, ok := os.LookupEnv("GOTRYCATCH_RETHROW_AT_OR_ABOVE"); ok {
+		if s, found := parseSeverity(raw); found {
+			apply = append(apply, func() { RethrowAtOrAbove = s })
+		} else {
+			errs = append(errs, fmt.Errorf("GOTRYCATCH_RETHROW_AT_OR_ABOVE: unknown severity %q", raw))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	for _, fn := range apply {
+		fn()
+	}
+	debugLog("ConfigureFromEnv: applied %d setting(s)", len(apply))
+	return nil
+}
+
+// parseSeverity converts a severity name as returned by Severity.String back into
+// a Severity. Matching is case-insensitive.
+func parseSeverity(name string) (Severity, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for s := SeverityUnknown; s <= SeverityCritical; s++ {
+		if s.String() == name {
+			return s, true
+		}
+	}
+	return SeverityUnknown, false
+}
@@ -0,0 +1,78 @@
+package gotrycatch
+
+import (
+	"strings"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// resetEnvFlags restores every flag ConfigureFromEnv can touch after the test.
+func resetEnvFlags(t *testing.T) {
+	t.Helper()
+	debug, stack, inFlight, ordering := debugMode, CaptureStack, TrackInFlight, DebugOrdering
+	location, rethrow := trycatcherrors.CaptureLocation, RethrowAtOrAbove
+	t.Cleanup(func() {
+		debugMode, CaptureStack, TrackInFlight, DebugOrdering = debug, stack, inFlight, ordering
+		trycatcherrors.CaptureLocation, RethrowAtOrAbove = location, rethrow
+	})
+}
+
+// ============================================
+// ConfigureFromEnv 测试
+// ============================================
+
+func TestConfigureFromEnv_SetsFlags(t *testing.T) {
+	resetEnvFlags(t)
+	t.Setenv("GOTRYCATCH_CAPTURE_STACK", "true")
+	t.Setenv("GOTRYCATCH_TRACK_IN_FLIGHT", "1")
+	t.Setenv("GOTRYCATCH_CAPTURE_LOCATION", "TRUE")
+	t.Setenv("GOTRYCATCH_RETHROW_AT_OR_ABOVE", "Critical")
+
+	if err := ConfigureFromEnv(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !CaptureStack || !TrackInFlight || !trycatcherrors.CaptureLocation {
+		t.Errorf("Expected flags to be enabled, got stack=%v inflight=%v location=%v",
+			CaptureStack, TrackInFlight, trycatcherrors.CaptureLocation)
+	}
+	if RethrowAtOrAbove != SeverityCritical {
+		t.Errorf("Expected RethrowAtOrAbove critical, got %v", RethrowAtOrAbove)
+	}
+	if DebugOrdering {
+		t.Error("Expected unset variable to leave DebugOrdering unchanged")
+	}
+}
+
+func TestConfigureFromEnv_Malformed(t *testing.T) {
+	resetEnvFlags(t)
+	CaptureStack = false
+	t.Setenv("GOTRYCATCH_CAPTURE_STACK", "true")
+	t.Setenv("GOTRYCATCH_DEBUG", "sometimes")
+	t.Setenv("GOTRYCATCH_RETHROW_AT_OR_ABOVE", "fatal")
+
+	err := ConfigureFromEnv()
+	if err == nil {
+		t.Fatal("Expected error for malformed values")
+	}
+	if !strings.Contains(err.Error(), "GOTRYCATCH_DEBUG") || !strings.Contains(err.Error(), "GOTRYCATCH_RETHROW_AT_OR_ABOVE") {
+		t.Errorf("Expected error to name both invalid variables, got %v", err)
+	}
+	if CaptureStack {
+		t.Error("Expected no flag to change when any value is malformed")
+	}
+}
+
+func TestConfigureFromEnv_Disable(t *testing.T) {
+	resetEnvFlags(t)
+	SetDebug(true)
+	t.Setenv("GOTRYCATCH_DEBUG", "false")
+
+	if err := ConfigureFromEnv(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if IsDebug() {
+		t.Error("Expected debug mode to be disabled")
+	}
+}
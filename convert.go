@@ -0,0 +1,34 @@
+package gotrycatch
+
+// ============================================
+// ValueOf / PtrOf - Value and pointer form conversion
+// ============================================
+
+// ValueOf returns err as a T whether it was thrown as a T or as a *T.
+// A nil *T does not match. The second result reports whether the conversion succeeded.
+func ValueOf[T any](err interface{}) (T, bool) {
+	switch v := err.(type) {
+	case T:
+		return v, true
+	case *T:
+		if v != nil {
+			return *v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// PtrOf returns err as a *T whether it was thrown as a *T or as a T.
+// When err is a T, the result points to a copy of it. A nil *T does not match.
+func PtrOf[T any](err interface{}) (*T, bool) {
+	switch v := err.(type) {
+	case *T:
+		if v != nil {
+			return v, true
+		}
+	case T:
+		return &v, true
+	}
+	return nil, false
+}
@@ -0,0 +1,67 @@
+package gotrycatch
+
+import (
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// ValueOf 测试
+// ============================================
+
+func TestValueOf_BothForms(t *testing.T) {
+	ve := trycatcherrors.NewValidationError("email", "invalid", 1001)
+
+	for _, thrown := range []interface{}{ve, &ve} {
+		got, ok := ValueOf[trycatcherrors.ValidationError](thrown)
+		if !ok {
+			t.Fatalf("Expected %T to convert", thrown)
+		}
+		if got.Field != "email" {
+			t.Errorf("Expected field 'email', got %s", got.Field)
+		}
+	}
+}
+
+func TestValueOf_NonMatching(t *testing.T) {
+	var nilPtr *trycatcherrors.ValidationError
+
+	for _, thrown := range []interface{}{nil, "boom", trycatcherrors.NewDatabaseError("SELECT", "t", nil), nilPtr} {
+		if _, ok := ValueOf[trycatcherrors.ValidationError](thrown); ok {
+			t.Errorf("Expected %T(%v) not to convert", thrown, thrown)
+		}
+	}
+}
+
+// ============================================
+// PtrOf 测试
+// ============================================
+
+func TestPtrOf_BothForms(t *testing.T) {
+	ve := trycatcherrors.NewValidationError("email", "invalid", 1001)
+
+	ptr, ok := PtrOf[trycatcherrors.ValidationError](&ve)
+	if !ok || ptr != &ve {
+		t.Error("Expected pointer form to be returned as-is")
+	}
+
+	ptr, ok = PtrOf[trycatcherrors.ValidationError](ve)
+	if !ok || ptr.Field != "email" {
+		t.Fatalf("Expected value form to convert, got %v %v", ptr, ok)
+	}
+	ptr.Field = "changed"
+	if ve.Field != "email" {
+		t.Error("Expected value form to be copied")
+	}
+}
+
+func TestPtrOf_NonMatching(t *testing.T) {
+	var nilPtr *trycatcherrors.ValidationError
+
+	for _, thrown := range []interface{}{nil, 42, nilPtr} {
+		if ptr, ok := PtrOf[trycatcherrors.ValidationError](thrown); ok || ptr != nil {
+			t.Errorf("Expected %T(%v) not to convert", thrown, thrown)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ============================================
+// ContextError - Lightweight structured panics
+// ============================================
+
+// badKey is used for a trailing key-value argument that has no partner, following log/slog.
+const badKey = "!BADKEY"
+
+// ContextError is a lightweight structured error carrying a message and key-value fields.
+// It is thrown by ThrowCtx.
+type ContextError struct {
+	Message string                 // Human-readable error message
+	Fields  map[string]interface{} // Structured context
+}
+
+// Error returns the message followed by the fields as sorted key=value pairs,
+// e.g. "user not found id=42 tenant=acme".
+func (e *ContextError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(e.Message)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%v", k, e.Fields[k])
+	}
+	return b.String()
+}
+
+// Field returns the value stored under key and whether it was present.
+func (e *ContextError) Field(key string) (interface{}, bool) {
+	val, ok := e.Fields[key]
+	return val, ok
+}
+
+// NewContextError builds a ContextError from alternating key/value arguments.
+// Non-string keys are formatted with %v. A trailing key without a value is stored
+// under the key "!BADKEY", as log/slog does.
+func NewContextError(msg string, kv ...interface{}) *ContextError {
+	fields := make(map[string]interface{}, len(kv)/2+1)
+	for i := 0; i < len(kv); i += 2 {
+		if i+1 >= len(kv) {
+			fields[badKey] = kv[i]
+			break
+		}
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprint(kv[i])
+		}
+		fields[key] = kv[i+1]
+	}
+	return &ContextError{Message: msg, Fields: fields}
+}
+
+// ThrowCtx throws a *ContextError built from msg and alternating key/value arguments.
+//
+//	gotrycatch.ThrowCtx("user not found", "id", 42, "tenant", "acme")
+func ThrowCtx(msg string, kv ...interface{}) {
+	Throw(NewContextError(msg, kv...))
+}
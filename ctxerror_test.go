@@ -0,0 +1,65 @@
+package gotrycatch
+
+import "testing"
+
+// ============================================
+// ContextError 测试
+// ============================================
+
+func TestThrowCtx_Fields(t *testing.T) {
+	var caught *ContextError
+	tb := Try(func() {
+		ThrowCtx("user not found", "id", 42, "tenant", "acme")
+	})
+	Catch[*ContextError](tb, func(err *ContextError) {
+		caught = err
+	})
+
+	if caught == nil {
+		t.Fatal("Expected *ContextError to be thrown")
+	}
+	if caught.Message != "user not found" {
+		t.Errorf("Expected message 'user not found', got %q", caught.Message)
+	}
+	if id, ok := caught.Field("id"); !ok || id != 42 {
+		t.Errorf("Expected id=42, got %v (ok=%v)", id, ok)
+	}
+	if tenant, ok := caught.Field("tenant"); !ok || tenant != "acme" {
+		t.Errorf("Expected tenant=acme, got %v (ok=%v)", tenant, ok)
+	}
+	if _, ok := caught.Field("missing"); ok {
+		t.Error("Expected missing field to be reported absent")
+	}
+}
+
+func TestContextError_Format(t *testing.T) {
+	err := NewContextError("user not found", "tenant", "acme", "id", 42)
+	if err.Error() != "user not found id=42 tenant=acme" {
+		t.Errorf("Unexpected format: %q", err.Error())
+	}
+
+	if NewContextError("plain").Error() != "plain" {
+		t.Errorf("Expected bare message without fields, got %q", NewContextError("plain").Error())
+	}
+}
+
+func TestContextError_OddArguments(t *testing.T) {
+	err := NewContextError("odd", "a", 1, "dangling")
+
+	if v, ok := err.Field("a"); !ok || v != 1 {
+		t.Errorf("Expected a=1, got %v", v)
+	}
+	if v, ok := err.Field("!BADKEY"); !ok || v != "dangling" {
+		t.Errorf("Expected dangling argument under !BADKEY, got %v (ok=%v)", v, ok)
+	}
+	if len(err.Fields) != 2 {
+		t.Errorf("Expected 2 fields, got %v", err.Fields)
+	}
+}
+
+func TestContextError_NonStringKey(t *testing.T) {
+	err := NewContextError("keys", 7, "seven")
+	if v, ok := err.Field("7"); !ok || v != "seven" {
+		t.Errorf("Expected non-string key to be formatted, got %v", err.Fields)
+	}
+}
@@ -0,0 +1,75 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ============================================
+// TryDepth - Catchable recursion limit
+// ============================================
+
+// RecursionLimitError is thrown by TryDepth when nested calls exceed the depth limit.
+type RecursionLimitError struct {
+	MaxDepth int // The limit that was exceeded
+}
+
+func (e RecursionLimitError) Error() string {
+	return fmt.Sprintf("recursion depth limit %d exceeded", e.MaxDepth)
+}
+
+var (
+	depthMu sync.Mutex
+	depths  = map[uint64]int{} // current TryDepth nesting per goroutine
+)
+
+// TryDepth runs fn with a per-goroutine recursion depth, giving runaway recursion a
+// catchable RecursionLimitError instead of a fatal stack overflow, which cannot be
+// recovered. Recursive code calls TryDepth again for each level; the outermost call
+// passes depth 0 to fn, and a nested call that would exceed maxDepth throws instead
+// of running fn.
+//
+// Only the outermost call recovers: it returns a TryBlock holding any panic from the
+// whole recursion, including the RecursionLimitError. Nested calls let panics propagate
+// to it and return a clean TryBlock.
+//
+//	var walk func(n *Node)
+//	walk = func(n *Node) {
+//		gotrycatch.TryDepth(1000, func(depth int) {
+//			for _, c := range n.Children {
+//				walk(c)
+//			}
+//		})
+//	}
+func TryDepth(maxDepth int, fn func(depth int)) *TryBlock {
+	gid := goroutineID()
+
+	depthMu.Lock()
+	depth := depths[gid]
+	depths[gid] = depth + 1
+	depthMu.Unlock()
+
+	defer func() {
+		depthMu.Lock()
+		if depth == 0 {
+			delete(depths, gid)
+		} else {
+			depths[gid] = depth
+		}
+		depthMu.Unlock()
+	}()
+
+	run := func() {
+		if depth > maxDepth {
+			debugLog("TryDepth: depth %d exceeds limit %d", depth, maxDepth)
+			Throw(RecursionLimitError{MaxDepth: maxDepth})
+		}
+		fn(depth)
+	}
+
+	if depth > 0 {
+		run()
+		return &TryBlock{}
+	}
+	return Try(run)
+}
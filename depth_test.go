@@ -0,0 +1,69 @@
+package gotrycatch
+
+import "testing"
+
+// ============================================
+// TryDepth 测试
+// ============================================
+
+func TestTryDepth_RunawayRecursion(t *testing.T) {
+	var deepest int
+	var recurse func()
+	recurse = func() {
+		TryDepth(50, func(depth int) {
+			deepest = depth
+			recurse()
+		})
+	}
+
+	tb := TryDepth(50, func(int) { recurse() })
+
+	var caught RecursionLimitError
+	Catch[RecursionLimitError](tb, func(e RecursionLimitError) { caught = e })
+	if caught.MaxDepth != 50 {
+		t.Errorf("Expected RecursionLimitError with limit 50, got %v", tb.GetError())
+	}
+	if deepest != 50 {
+		t.Errorf("Expected recursion to stop at depth 50, got %d", deepest)
+	}
+}
+
+func TestTryDepth_WithinLimit(t *testing.T) {
+	var sum func(n int) int
+	sum = func(n int) int {
+		var result int
+		TryDepth(10, func(int) {
+			if n > 0 {
+				result = n + sum(n-1)
+			}
+		})
+		return result
+	}
+
+	if got := sum(10); got != 55 {
+		t.Errorf("Expected 55, got %d", got)
+	}
+
+	// Depth is released after the outermost call returns.
+	tb := TryDepth(0, func(depth int) {
+		if depth != 0 {
+			t.Errorf("Expected depth 0 for a fresh call, got %d", depth)
+		}
+	})
+	if tb.HasError() {
+		t.Errorf("Expected clean block, got %v", tb.GetError())
+	}
+}
+
+func TestTryDepth_OtherPanics(t *testing.T) {
+	tb := TryDepth(5, func(int) {
+		TryDepth(5, func(int) { panic("inner failure") })
+	})
+
+	if tb.GetError() != "inner failure" {
+		t.Errorf("Expected nested panic at the outermost block, got %v", tb.GetError())
+	}
+	if len(depths) != 0 {
+		t.Errorf("Expected depth counters to be released, got %v", depths)
+	}
+}
@@ -0,0 +1,59 @@
+package gotrycatch
+
+import (
+	"reflect"
+	"sync"
+)
+
+// ============================================
+// Describe - Custom formatting of panic values for logs
+// ============================================
+
+var (
+	formattersMu sync.RWMutex
+	formatters   = map[reflect.Type]func(interface{}) string{}
+)
+
+// RegisterFormatter makes Describe, and the reports built on it, format values of the
+// same dynamic type as sample with format. It lets third-party panic values that print
+// poorly be described without implementing fmt.Stringer on them. Registering a type
+// again replaces its formatter; a nil format removes it. A nil sample is ignored.
+func RegisterFormatter(sample interface{}, format func(interface{}) string) {
+	if sample == nil {
+		debugLog("RegisterFormatter: sample is nil, ignoring")
+		return
+	}
+
+	t := reflect.TypeOf(sample)
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+	if format == nil {
+		delete(formatters, t)
+		return
+	}
+	formatters[t] = format
+}
+
+// Describe returns a human-readable description of a panic value for logs.
+// A formatter registered with RegisterFormatter for the value's type takes precedence;
+// otherwise errors contribute their Error() string and other values are formatted
+// with %v. If a registered formatter panics, the default description is used.
+// Returns "" for nil.
+func Describe(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+
+	formattersMu.RLock()
+	format := formatters[reflect.TypeOf(v)]
+	formattersMu.RUnlock()
+
+	if format != nil {
+		var s string
+		if r := recoverFrom(func() { s = format(v) }); r == nil {
+			return s
+		}
+		debugLog("Describe: formatter for %T panicked, using default description", v)
+	}
+	return errorMessage(v)
+}
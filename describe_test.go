@@ -0,0 +1,67 @@
+package gotrycatch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// ============================================
+// Describe 测试
+// ============================================
+
+type opaquePanic struct {
+	code int
+	raw  []byte
+}
+
+func TestDescribe_Default(t *testing.T) {
+	if got := Describe(errors.New("disk full")); got != "disk full" {
+		t.Errorf("Expected error message, got %q", got)
+	}
+	if got := Describe(42); got != "42" {
+		t.Errorf("Expected %%v formatting, got %q", got)
+	}
+	if got := Describe(nil); got != "" {
+		t.Errorf("Expected empty description for nil, got %q", got)
+	}
+}
+
+func TestDescribe_RegisteredFormatter(t *testing.T) {
+	RegisterFormatter(opaquePanic{}, func(v interface{}) string {
+		return fmt.Sprintf("opaque code %d", v.(opaquePanic).code)
+	})
+	t.Cleanup(func() { RegisterFormatter(opaquePanic{}, nil) })
+
+	value := opaquePanic{code: 7, raw: []byte{1, 2, 3}}
+	if got := Describe(value); got != "opaque code 7" {
+		t.Errorf("Expected registered formatter to be used, got %q", got)
+	}
+
+	tb := Try(func() { panic(value) })
+	if !strings.Contains(tb.String(), "opaque code 7") {
+		t.Errorf("Expected TryBlock.String to use the formatter, got %q", tb.String())
+	}
+	if chain := CauseChain(value); len(chain) != 1 || chain[0] != "opaque code 7" {
+		t.Errorf("Expected CauseChain to use the formatter, got %v", chain)
+	}
+}
+
+func TestDescribe_UnregisterAndPanickingFormatter(t *testing.T) {
+	RegisterFormatter(opaquePanic{}, func(interface{}) string { panic("formatter bug") })
+	t.Cleanup(func() { RegisterFormatter(opaquePanic{}, nil) })
+
+	value := opaquePanic{code: 1}
+	if got := Describe(value); got != fmt.Sprint(value) {
+		t.Errorf("Expected default description when formatter panics, got %q", got)
+	}
+
+	RegisterFormatter(opaquePanic{}, func(interface{}) string { return "custom" })
+	RegisterFormatter(opaquePanic{}, nil)
+	if got := Describe(value); got != fmt.Sprint(value) {
+		t.Errorf("Expected formatter to be removed, got %q", got)
+	}
+
+	RegisterFormatter(nil, func(interface{}) string { return "ignored" })
+}
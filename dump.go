@@ -0,0 +1,117 @@
+package gotrycatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ============================================
+// DumpPanic - Structured crash reports
+// ============================================
+
+// DumpFormat selects the output format of DumpPanic.
+type DumpFormat int
+
+const (
+	// DumpText writes a human-readable report with one section per line.
+	DumpText DumpFormat = iota
+	// DumpJSON writes the report as a single JSON object.
+	DumpJSON
+)
+
+// CrashReport is the content written by DumpPanic.
+type CrashReport struct {
+	Time        time.Time              `json:"time"`
+	Type        string                 `json:"type"`
+	Message     string                 `json:"message"`
+	Fingerprint string                 `json:"fingerprint"`
+	Severity    string                 `json:"severity"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+	Environment map[string]string      `json:"environment,omitempty"`
+	Stack       []string               `json:"stack,omitempty"`
+}
+
+// dumpNow is a variable so tests can fix the report time.
+var dumpNow = time.Now
+
+// DumpPanic writes a crash report for the error captured by tb to w, so post-mortem
+// dumps look the same across services. The report contains the time, error type and
+// message, Fingerprint, SeverityOf, the error's structured fields (from ToMap), the
+// environment snapshot taken when the panic was recovered (see SnapshotFunc) and a
+// stack. The stack is the panic stack when CaptureStack was enabled, otherwise the
+// stack carried by the error itself, if any.
+// Nothing is written for a nil or clean block. Returns any error from writing to w.
+func DumpPanic(w io.Writer, tb *TryBlock, format DumpFormat) error {
+	if tb == nil || tb.err == nil {
+		return nil
+	}
+
+	report := CrashReport{
+		Time:        dumpNow(),
+		Type:        TypeName(tb.err),
+		Message:     Describe(tb.err),
+		Fingerprint: Fingerprint(tb.err),
+		Severity:    SeverityOf(tb.err).String(),
+		Environment: tb.Snapshot(),
+		Stack:       panicStack(tb),
+	}
+	if m, ok := tb.err.(interface{ ToMap() map[string]interface{} }); ok {
+		report.Fields = m.ToMap()
+		delete(report.Fields, "type")
+		delete(report.Fields, "stack")
+	}
+
+	if format == DumpJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	_, err := io.WriteString(w, formatCrashReport(report))
+	return err
+}
+
+func formatCrashReport(r CrashReport) string {
+	var b strings.Builder
+	b.WriteString("=== gotrycatch crash report ===\n")
+	fmt.Fprintf(&b, "time:        %s\n", r.Time.Format(time.RFC3339Nano))
+	fmt.Fprintf(&b, "type:        %s\n", r.Type)
+	fmt.Fprintf(&b, "message:     %s\n", r.Message)
+	fmt.Fprintf(&b, "fingerprint: %s\n", r.Fingerprint)
+	fmt.Fprintf(&b, "severity:    %s\n", r.Severity)
+
+	if len(r.Fields) > 0 {
+		keys := make([]string, 0, len(r.Fields))
+		for k := range r.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b.WriteString("fields:\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "  %s: %v\n", k, r.Fields[k])
+		}
+	}
+	if len(r.Environment) > 0 {
+		keys := make([]string, 0, len(r.Environment))
+		for k := range r.Environment {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b.WriteString("environment:\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "  %s: %s\n", k, r.Environment[k])
+		}
+	}
+	if len(r.Stack) > 0 {
+		b.WriteString("stack:\n")
+		for _, frame := range r.Stack {
+			fmt.Fprintf(&b, "  %s\n", frame)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package gotrycatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// fixDumpTime pins the time used by DumpPanic for the duration of the test.
+func fixDumpTime(t *testing.T) time.Time {
+	t.Helper()
+	clock := newFakeClock()
+	original := dumpNow
+	dumpNow = clock.Now
+	t.Cleanup(func() { dumpNow = original })
+	return clock.Now()
+}
+
+// ============================================
+// DumpPanic 测试
+// ============================================
+
+func TestDumpPanic_Text(t *testing.T) {
+	now := fixDumpTime(t)
+	CaptureStack = true
+	defer func() { CaptureStack = false }()
+
+	dbErr := trycatcherrors.NewDatabaseError("SELECT", "users", errors.New("timeout"))
+	tb := Try(func() { panic(dbErr) })
+
+	var buf bytes.Buffer
+	if err := DumpPanic(&buf, tb, DumpText); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	report := buf.String()
+
+	for _, want := range []string{
+		"=== gotrycatch crash report ===",
+		"time:        " + now.Format(time.RFC3339Nano),
+		"type:        errors.DatabaseError",
+		"message:     " + dbErr.Error(),
+		"fingerprint: " + Fingerprint(dbErr),
+		"severity:    critical",
+		"fields:\n",
+		"  table: users\n",
+		"stack:\n",
+		"TestDumpPanic_Text",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+}
+
+func TestDumpPanic_JSON(t *testing.T) {
+	fixDumpTime(t)
+
+	tb := Try(func() { panic(trycatcherrors.NewValidationError("email", "invalid", 1001)) })
+
+	var buf bytes.Buffer
+	if err := DumpPanic(&buf, tb, DumpJSON); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var report CrashReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %s", err, buf.String())
+	}
+	if report.Type != "errors.ValidationError" || report.Severity != "warning" {
+		t.Errorf("Unexpected type or severity: %+v", report)
+	}
+	if report.Fields["field"] != "email" {
+		t.Errorf("Expected field 'email', got %v", report.Fields["field"])
+	}
+	if _, ok := report.Fields["stack"]; ok {
+		t.Error("Expected stack to be reported separately from fields")
+	}
+	if len(report.Stack) == 0 {
+		t.Error("Expected the error's own stack when CaptureStack is off")
+	}
+}
+
+func TestDumpPanic_CleanBlock(t *testing.T) {
+	var buf bytes.Buffer
+	if err := DumpPanic(&buf, Try(func() {}), DumpText); err != nil || buf.Len() != 0 {
+		t.Errorf("Expected nothing written for clean block, got %q (%v)", buf.String(), err)
+	}
+}
@@ -7,13 +7,21 @@
 //   - Call stack tracing
 //   - Error chains (Unwrap/Is/As)
 //   - Structured output (ToMap/ToJSON)
+//   - Structured logging (slog.LogValuer)
 //   - Timestamp recording
 package errors
 
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path/filepath"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -63,6 +71,78 @@ func captureCaller(skip int) (file string, line int, function string) {
 	return file, line, fn.Name()
 }
 
+// CaptureLocation controls whether the New* constructors record their caller as a
+// compact file:line string in the Location field. It is off by default.
+var CaptureLocation = false
+
+// location formats the creation site for the Location field, or returns "" when
+// CaptureLocation is off.
+func location(file string, line int) string {
+	if !CaptureLocation {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
+
+// logValue converts a ToMap result into a slog group value. The type comes first and
+// the remaining fields follow in key order; the stack is left out to keep logs compact.
+func logValue(m map[string]interface{}) slog.Value {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if k != "type" && k != "stack" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys)+1)
+	if t, ok := m["type"]; ok {
+		attrs = append(attrs, slog.Any("type", t))
+	}
+	for _, k := range keys {
+		attrs = append(attrs, slog.Any(k, m[k]))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// cloneStack returns an independent copy of a stack trace slice.
+func cloneStack(stack []string) []string {
+	if stack == nil {
+		return nil
+	}
+	return append([]string(nil), stack...)
+}
+
+// IsBuiltin reports whether err is one of the error types defined in this package,
+// either as a value or as a pointer.
+func IsBuiltin(err interface{}) bool {
+	return BuiltinKind(err) != ""
+}
+
+// BuiltinKind returns a short name for the family of a built-in error type:
+// "validation", "database", "network", "business", "config", "auth" or "ratelimit".
+// Both value and pointer forms are recognized. Returns "" for any other value.
+func BuiltinKind(err interface{}) string {
+	switch err.(type) {
+	case ValidationError, *ValidationError, ValidationErrors, *ValidationErrors, ValidationTree, *ValidationTree:
+		return "validation"
+	case DatabaseError, *DatabaseError, BatchDatabaseError, *BatchDatabaseError:
+		return "database"
+	case NetworkError, *NetworkError:
+		return "network"
+	case BusinessLogicError, *BusinessLogicError:
+		return "business"
+	case ConfigError, *ConfigError:
+		return "config"
+	case AuthError, *AuthError:
+		return "auth"
+	case RateLimitError, *RateLimitError:
+		return "ratelimit"
+	default:
+		return ""
+	}
+}
+
 // ============================================
 // ValidationError - Data validation errors
 // ============================================
@@ -75,15 +155,17 @@ func captureCaller(skip int) (file string, line int, function string) {
 //   - File, Line, Function: source location where the error was created
 //   - Timestamp: when the error occurred
 //   - Stack: call stack trace
+//   - Location: creation site, only when CaptureLocation is enabled
 type ValidationError struct {
-	Field     string    `json:"field"`     // Field that failed validation
-	Message   string    `json:"message"`   // Human-readable error message
-	Code      int       `json:"code"`      // Error code for programmatic handling
-	File      string    `json:"file"`      // Source file name
-	Line      int       `json:"line"`      // Line number
-	Function  string    `json:"function"`  // Function name
-	Timestamp time.Time `json:"timestamp"` // When error occurred
-	Stack     []string  `json:"stack"`     // Call stack trace
+	Field     string    `json:"field"`              // Field that failed validation
+	Message   string    `json:"message"`            // Human-readable error message
+	Code      int       `json:"code"`               // Error code for programmatic handling
+	File      string    `json:"file"`               // Source file name
+	Line      int       `json:"line"`               // Line number
+	Function  string    `json:"function"`           // Function name
+	Timestamp time.Time `json:"timestamp"`          // When error occurred
+	Stack     []string  `json:"stack"`              // Call stack trace
+	Location  string    `json:"location,omitempty"` // Creation site as file:line, set when CaptureLocation is on
 }
 
 func (e ValidationError) Error() string {
@@ -124,6 +206,22 @@ func (e ValidationError) ToJSON() ([]byte, error) {
 	return json.Marshal(e.ToMap())
 }
 
+// LogValue implements slog.LogValuer, logging the error as a group of its fields.
+func (e ValidationError) LogValue() slog.Value {
+	return logValue(e.ToMap())
+}
+
+// Clone returns a deep copy of the error, so it can be annotated without affecting the original.
+func (e ValidationError) Clone() ValidationError {
+	e.Stack = cloneStack(e.Stack)
+	return e
+}
+
+// Where returns the creation site as file:line when CaptureLocation was enabled, or "" otherwise.
+func (e ValidationError) Where() string {
+	return e.Location
+}
+
 // NewValidationError creates a new ValidationError with automatic stack capture.
 func NewValidationError(field, message string, code int) ValidationError {
 	file, line, fn := captureCaller(1)
@@ -143,7 +241,226 @@ func NewValidationError(field, message string, code int) ValidationError {
 		Function:  fn,
 		Timestamp: time.Now(),
 		Stack:     stackStrs,
+		Location:  location(file, line),
+	}
+}
+
+// NewValidationErrorf is like NewValidationError but formats the message with fmt.Sprintf.
+func NewValidationErrorf(field string, code int, format string, args ...interface{}) ValidationError {
+	file, line, fn := captureCaller(1)
+	stack := captureStack(1)
+
+	var stackStrs []string
+	for _, s := range stack {
+		stackStrs = append(stackStrs, fmt.Sprintf("%s:%d %s", s.File, s.Line, s.Func))
+	}
+
+	return ValidationError{
+		Field:     field,
+		Message:   fmt.Sprintf(format, args...),
+		Code:      code,
+		File:      file,
+		Line:      line,
+		Function:  fn,
+		Timestamp: time.Now(),
+		Stack:     stackStrs,
+		Location:  location(file, line),
+	}
+}
+
+// ============================================
+// ValidationErrors - Aggregated validation failures
+// ============================================
+
+// ValidationErrors aggregates several ValidationError values, e.g. all failed fields of a form.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	msgs := make([]string, len(e))
+	for i, ve := range e {
+		msgs[i] = fmt.Sprintf("%s: %s", ve.Field, ve.Message)
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the individual validation errors.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, ve := range e {
+		errs[i] = ve
+	}
+	return errs
+}
+
+// Fields returns the names of the failed fields in order.
+func (e ValidationErrors) Fields() []string {
+	fields := make([]string, len(e))
+	for i, ve := range e {
+		fields[i] = ve.Field
+	}
+	return fields
+}
+
+// ToMap returns structured error information.
+func (e ValidationErrors) ToMap() map[string]interface{} {
+	errs := make([]map[string]interface{}, len(e))
+	for i, ve := range e {
+		errs[i] = ve.ToMap()
+	}
+	return map[string]interface{}{
+		"type":   "ValidationErrors",
+		"count":  len(e),
+		"errors": errs,
+	}
+}
+
+// ToJSON returns JSON-formatted error information.
+func (e ValidationErrors) ToJSON() ([]byte, error) {
+	return json.Marshal(e.ToMap())
+}
+
+// LogValue implements slog.LogValuer, logging the error as a group of its fields.
+func (e ValidationErrors) LogValue() slog.Value {
+	return logValue(e.ToMap())
+}
+
+// Clone returns a deep copy of the aggregate and of every contained ValidationError.
+func (e ValidationErrors) Clone() ValidationErrors {
+	if e == nil {
+		return nil
+	}
+	clone := make(ValidationErrors, len(e))
+	for i, ve := range e {
+		clone[i] = ve.Clone()
 	}
+	return clone
+}
+
+// ============================================
+// ValidationTree - Nested validation failures
+// ============================================
+
+// ValidationTree groups validation failures of nested payloads under dotted paths,
+// such as "items.0" for the first line item of an order. The zero value is ready to use.
+type ValidationTree struct {
+	Errors   ValidationErrors           // Failures at this level
+	Children map[string]*ValidationTree // Nested levels keyed by path segment
+}
+
+// NewValidationTree creates an empty ValidationTree.
+func NewValidationTree() *ValidationTree {
+	return &ValidationTree{}
+}
+
+// AddAt records ve under the dotted path, creating intermediate levels as needed.
+// An empty path adds ve at the root.
+func (t *ValidationTree) AddAt(path string, ve ValidationError) {
+	node := t
+	if path != "" {
+		for _, segment := range strings.Split(path, ".") {
+			if node.Children == nil {
+				node.Children = make(map[string]*ValidationTree)
+			}
+			child, ok := node.Children[segment]
+			if !ok {
+				child = &ValidationTree{}
+				node.Children[segment] = child
+			}
+			node = child
+		}
+	}
+	node.Errors = append(node.Errors, ve)
+}
+
+// Flatten returns every failure in the tree with its Field replaced by the full
+// dotted path, e.g. "items.0.quantity". Failures at a level come before those of its
+// children, and children are ordered by key, with numeric keys in numeric order.
+func (t *ValidationTree) Flatten() []ValidationError {
+	var out []ValidationError
+	t.flatten("", &out)
+	return out
+}
+
+func (t *ValidationTree) flatten(prefix string, out *[]ValidationError) {
+	for _, ve := range t.Errors {
+		ve.Field = joinPath(prefix, ve.Field)
+		*out = append(*out, ve)
+	}
+
+	keys := make([]string, 0, len(t.Children))
+	for k := range t.Children {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+	for _, k := range keys {
+		t.Children[k].flatten(joinPath(prefix, k), out)
+	}
+}
+
+func joinPath(prefix, field string) string {
+	switch {
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	default:
+		return prefix + "." + field
+	}
+}
+
+// Len returns the total number of failures in the tree.
+func (t *ValidationTree) Len() int {
+	n := len(t.Errors)
+	for _, child := range t.Children {
+		n += child.Len()
+	}
+	return n
+}
+
+// Error formats the flattened failures like ValidationErrors, so a tree can be thrown.
+func (t *ValidationTree) Error() string {
+	return ValidationErrors(t.Flatten()).Error()
+}
+
+// Unwrap returns the flattened failures, so errors.As and CatchChain reach them with
+// their full dotted paths.
+func (t *ValidationTree) Unwrap() []error {
+	return ValidationErrors(t.Flatten()).Unwrap()
+}
+
+// ToMap returns structured error information for Agent parsing. The failures are
+// flattened, with each field given as its full dotted path.
+func (t *ValidationTree) ToMap() map[string]interface{} {
+	flat := t.Flatten()
+	errs := make([]map[string]interface{}, len(flat))
+	for i, ve := range flat {
+		errs[i] = ve.ToMap()
+	}
+	return map[string]interface{}{
+		"type":   "ValidationTree",
+		"count":  len(flat),
+		"errors": errs,
+	}
+}
+
+// ToJSON returns JSON-formatted error information.
+func (t *ValidationTree) ToJSON() ([]byte, error) {
+	return json.Marshal(t.ToMap())
+}
+
+// LogValue implements slog.LogValuer, logging the error as a group of its fields.
+func (t *ValidationTree) LogValue() slog.Value {
+	return logValue(t.ToMap())
 }
 
 // ============================================
@@ -158,15 +475,17 @@ func NewValidationError(field, message string, code int) ValidationError {
 //   - File, Line, Function: source location
 //   - Timestamp: when error occurred
 //   - Stack: call stack trace
+//   - Location: creation site, only when CaptureLocation is enabled
 type DatabaseError struct {
-	Operation string    `json:"operation"` // Database operation (SELECT, INSERT, UPDATE, DELETE)
-	Table     string    `json:"table"`     // Table name involved
-	Cause     error     `json:"cause"`     // Underlying error
-	File      string    `json:"file"`      // Source file name
-	Line      int       `json:"line"`      // Line number
-	Function  string    `json:"function"`  // Function name
-	Timestamp time.Time `json:"timestamp"` // When error occurred
-	Stack     []string  `json:"stack"`     // Call stack trace
+	Operation string    `json:"operation"`          // Database operation (SELECT, INSERT, UPDATE, DELETE)
+	Table     string    `json:"table"`              // Table name involved
+	Cause     error     `json:"cause"`              // Underlying error
+	File      string    `json:"file"`               // Source file name
+	Line      int       `json:"line"`               // Line number
+	Function  string    `json:"function"`           // Function name
+	Timestamp time.Time `json:"timestamp"`          // When error occurred
+	Stack     []string  `json:"stack"`              // Call stack trace
+	Location  string    `json:"location,omitempty"` // Creation site as file:line, set when CaptureLocation is on
 }
 
 func (e DatabaseError) Error() string {
@@ -211,6 +530,23 @@ func (e DatabaseError) ToJSON() ([]byte, error) {
 	return json.Marshal(e.ToMap())
 }
 
+// LogValue implements slog.LogValuer, logging the error as a group of its fields.
+func (e DatabaseError) LogValue() slog.Value {
+	return logValue(e.ToMap())
+}
+
+// Clone returns a deep copy of the error, so it can be annotated without affecting the original.
+// The Cause is shared, since errors are conventionally immutable.
+func (e DatabaseError) Clone() DatabaseError {
+	e.Stack = cloneStack(e.Stack)
+	return e
+}
+
+// Where returns the creation site as file:line when CaptureLocation was enabled, or "" otherwise.
+func (e DatabaseError) Where() string {
+	return e.Location
+}
+
 // NewDatabaseError creates a new DatabaseError with automatic stack capture.
 func NewDatabaseError(operation, table string, cause error) DatabaseError {
 	file, line, fn := captureCaller(1)
@@ -230,6 +566,31 @@ func NewDatabaseError(operation, table string, cause error) DatabaseError {
 		Function:  fn,
 		Timestamp: time.Now(),
 		Stack:     stackStrs,
+		Location:  location(file, line),
+	}
+}
+
+// NewDatabaseErrorf is like NewDatabaseError but builds the cause with fmt.Errorf,
+// so %w can wrap an underlying error.
+func NewDatabaseErrorf(operation, table string, format string, args ...interface{}) DatabaseError {
+	file, line, fn := captureCaller(1)
+	stack := captureStack(1)
+
+	var stackStrs []string
+	for _, s := range stack {
+		stackStrs = append(stackStrs, fmt.Sprintf("%s:%d %s", s.File, s.Line, s.Func))
+	}
+
+	return DatabaseError{
+		Operation: operation,
+		Table:     table,
+		Cause:     fmt.Errorf(format, args...),
+		File:      file,
+		Line:      line,
+		Function:  fn,
+		Timestamp: time.Now(),
+		Stack:     stackStrs,
+		Location:  location(file, line),
 	}
 }
 
@@ -242,18 +603,22 @@ func NewDatabaseError(operation, table string, cause error) DatabaseError {
 //   - URL: the requested URL
 //   - StatusCode: HTTP status code (if applicable)
 //   - Timeout: whether the error was caused by a timeout
+//   - Headers: response headers (if available)
 //   - File, Line, Function: source location
 //   - Timestamp: when error occurred
 //   - Stack: call stack trace
+//   - Location: creation site, only when CaptureLocation is enabled
 type NetworkError struct {
-	URL        string    `json:"url"`        // Requested URL
-	StatusCode int       `json:"statusCode"` // HTTP status code (if applicable)
-	Timeout    bool      `json:"timeout"`    // Whether caused by timeout
-	File       string    `json:"file"`       // Source file name
-	Line       int       `json:"line"`       // Line number
-	Function   string    `json:"function"`   // Function name
-	Timestamp  time.Time `json:"timestamp"`  // When error occurred
-	Stack      []string  `json:"stack"`      // Call stack trace
+	URL        string      `json:"url"`                // Requested URL
+	StatusCode int         `json:"statusCode"`         // HTTP status code (if applicable)
+	Timeout    bool        `json:"timeout"`            // Whether caused by timeout
+	Headers    http.Header `json:"headers,omitempty"`  // Response headers (if available)
+	File       string      `json:"file"`               // Source file name
+	Line       int         `json:"line"`               // Line number
+	Function   string      `json:"function"`           // Function name
+	Timestamp  time.Time   `json:"timestamp"`          // When error occurred
+	Stack      []string    `json:"stack"`              // Call stack trace
+	Location   string      `json:"location,omitempty"` // Creation site as file:line, set when CaptureLocation is on
 }
 
 func (e NetworkError) Error() string {
@@ -277,6 +642,61 @@ func (e NetworkError) Is(target error) bool {
 	return e.URL == t.URL && e.Timeout == t.Timeout
 }
 
+// RetryAfter parses the Retry-After response header, accepting both the delay-seconds
+// and the HTTP-date forms. An HTTP-date in the past yields a zero delay.
+// Returns false if the header is missing or malformed.
+func (e NetworkError) RetryAfter() (time.Duration, bool) {
+	value := strings.TrimSpace(e.Headers.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		delay := time.Until(at)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// Retryable reports whether repeating the request may succeed: timeouts, failures
+// without a response (status 0), and the transient statuses 408, 429, 500, 502, 503
+// and 504. Other client and server errors are not retryable.
+func (e NetworkError) Retryable() bool {
+	if e.Timeout {
+		return true
+	}
+	switch e.StatusCode {
+	case 0, http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Host parses URL and returns its lower-cased host name, without the port, so failures
+// can be grouped by the server they targeted. Returns an error if URL is malformed or
+// has no host, e.g. a relative path.
+func (e NetworkError) Host() (string, error) {
+	u, err := url.Parse(e.URL)
+	if err != nil {
+		return "", err
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("no host in URL %q", e.URL)
+	}
+	return strings.ToLower(u.Hostname()), nil
+}
+
 // ToMap returns structured error information.
 func (e NetworkError) ToMap() map[string]interface{} {
 	return map[string]interface{}{
@@ -284,6 +704,7 @@ func (e NetworkError) ToMap() map[string]interface{} {
 		"url":        e.URL,
 		"statusCode": e.StatusCode,
 		"timeout":    e.Timeout,
+		"headers":    e.Headers,
 		"file":       e.File,
 		"line":       e.Line,
 		"function":   e.Function,
@@ -297,6 +718,24 @@ func (e NetworkError) ToJSON() ([]byte, error) {
 	return json.Marshal(e.ToMap())
 }
 
+// LogValue implements slog.LogValuer, logging the error as a group of its fields.
+func (e NetworkError) LogValue() slog.Value {
+	return logValue(e.ToMap())
+}
+
+// Clone returns a deep copy of the error, including its Headers,
+// so it can be annotated without affecting the original.
+func (e NetworkError) Clone() NetworkError {
+	e.Stack = cloneStack(e.Stack)
+	e.Headers = e.Headers.Clone()
+	return e
+}
+
+// Where returns the creation site as file:line when CaptureLocation was enabled, or "" otherwise.
+func (e NetworkError) Where() string {
+	return e.Location
+}
+
 // NewNetworkError creates a new NetworkError with a status code and automatic stack capture.
 func NewNetworkError(url string, statusCode int) NetworkError {
 	file, line, fn := captureCaller(1)
@@ -316,6 +755,32 @@ func NewNetworkError(url string, statusCode int) NetworkError {
 		Function:   fn,
 		Timestamp:  time.Now(),
 		Stack:      stackStrs,
+		Location:   location(file, line),
+	}
+}
+
+// NewNetworkErrorWithHeaders creates a new NetworkError with a status code and the
+// response headers, with automatic stack capture.
+func NewNetworkErrorWithHeaders(url string, statusCode int, headers http.Header) NetworkError {
+	file, line, fn := captureCaller(1)
+	stack := captureStack(1)
+
+	var stackStrs []string
+	for _, s := range stack {
+		stackStrs = append(stackStrs, fmt.Sprintf("%s:%d %s", s.File, s.Line, s.Func))
+	}
+
+	return NetworkError{
+		URL:        url,
+		StatusCode: statusCode,
+		Timeout:    false,
+		Headers:    headers,
+		File:       file,
+		Line:       line,
+		Function:   fn,
+		Timestamp:  time.Now(),
+		Stack:      stackStrs,
+		Location:   location(file, line),
 	}
 }
 
@@ -337,6 +802,7 @@ func NewNetworkTimeoutError(url string) NetworkError {
 		Function:  fn,
 		Timestamp: time.Now(),
 		Stack:     stackStrs,
+		Location:  location(file, line),
 	}
 }
 
@@ -351,14 +817,16 @@ func NewNetworkTimeoutError(url string) NetworkError {
 //   - File, Line, Function: source location
 //   - Timestamp: when error occurred
 //   - Stack: call stack trace
+//   - Location: creation site, only when CaptureLocation is enabled
 type BusinessLogicError struct {
-	Rule      string    `json:"rule"`      // Violated business rule name
-	Details   string    `json:"details"`   // Violation details
-	File      string    `json:"file"`      // Source file name
-	Line      int       `json:"line"`      // Line number
-	Function  string    `json:"function"`  // Function name
-	Timestamp time.Time `json:"timestamp"` // When error occurred
-	Stack     []string  `json:"stack"`     // Call stack trace
+	Rule      string    `json:"rule"`               // Violated business rule name
+	Details   string    `json:"details"`            // Violation details
+	File      string    `json:"file"`               // Source file name
+	Line      int       `json:"line"`               // Line number
+	Function  string    `json:"function"`           // Function name
+	Timestamp time.Time `json:"timestamp"`          // When error occurred
+	Stack     []string  `json:"stack"`              // Call stack trace
+	Location  string    `json:"location,omitempty"` // Creation site as file:line, set when CaptureLocation is on
 }
 
 func (e BusinessLogicError) Error() string {
@@ -398,6 +866,22 @@ func (e BusinessLogicError) ToJSON() ([]byte, error) {
 	return json.Marshal(e.ToMap())
 }
 
+// LogValue implements slog.LogValuer, logging the error as a group of its fields.
+func (e BusinessLogicError) LogValue() slog.Value {
+	return logValue(e.ToMap())
+}
+
+// Clone returns a deep copy of the error, so it can be annotated without affecting the original.
+func (e BusinessLogicError) Clone() BusinessLogicError {
+	e.Stack = cloneStack(e.Stack)
+	return e
+}
+
+// Where returns the creation site as file:line when CaptureLocation was enabled, or "" otherwise.
+func (e BusinessLogicError) Where() string {
+	return e.Location
+}
+
 // NewBusinessLogicError creates a new BusinessLogicError with automatic stack capture.
 func NewBusinessLogicError(rule, details string) BusinessLogicError {
 	file, line, fn := captureCaller(1)
@@ -416,6 +900,29 @@ func NewBusinessLogicError(rule, details string) BusinessLogicError {
 		Function:  fn,
 		Timestamp: time.Now(),
 		Stack:     stackStrs,
+		Location:  location(file, line),
+	}
+}
+
+// NewBusinessLogicErrorf is like NewBusinessLogicError but formats the details with fmt.Sprintf.
+func NewBusinessLogicErrorf(rule, format string, args ...interface{}) BusinessLogicError {
+	file, line, fn := captureCaller(1)
+	stack := captureStack(1)
+
+	var stackStrs []string
+	for _, s := range stack {
+		stackStrs = append(stackStrs, fmt.Sprintf("%s:%d %s", s.File, s.Line, s.Func))
+	}
+
+	return BusinessLogicError{
+		Rule:      rule,
+		Details:   fmt.Sprintf(format, args...),
+		File:      file,
+		Line:      line,
+		Function:  fn,
+		Timestamp: time.Now(),
+		Stack:     stackStrs,
+		Location:  location(file, line),
 	}
 }
 
@@ -431,15 +938,17 @@ func NewBusinessLogicError(rule, details string) BusinessLogicError {
 //   - File, Line, Function: source location
 //   - Timestamp: when error occurred
 //   - Stack: call stack trace
+//   - Location: creation site, only when CaptureLocation is enabled
 type ConfigError struct {
-	Key       string    `json:"key"`       // Configuration key name
-	Value     string    `json:"value"`     // Configuration value
-	Reason    string    `json:"reason"`    // Error reason
-	File      string    `json:"file"`      // Source file name
-	Line      int       `json:"line"`      // Line number
-	Function  string    `json:"function"`  // Function name
-	Timestamp time.Time `json:"timestamp"` // When error occurred
-	Stack     []string  `json:"stack"`     // Call stack trace
+	Key       string    `json:"key"`                // Configuration key name
+	Value     string    `json:"value"`              // Configuration value
+	Reason    string    `json:"reason"`             // Error reason
+	File      string    `json:"file"`               // Source file name
+	Line      int       `json:"line"`               // Line number
+	Function  string    `json:"function"`           // Function name
+	Timestamp time.Time `json:"timestamp"`          // When error occurred
+	Stack     []string  `json:"stack"`              // Call stack trace
+	Location  string    `json:"location,omitempty"` // Creation site as file:line, set when CaptureLocation is on
 }
 
 func (e ConfigError) Error() string {
@@ -480,6 +989,22 @@ func (e ConfigError) ToJSON() ([]byte, error) {
 	return json.Marshal(e.ToMap())
 }
 
+// LogValue implements slog.LogValuer, logging the error as a group of its fields.
+func (e ConfigError) LogValue() slog.Value {
+	return logValue(e.ToMap())
+}
+
+// Clone returns a deep copy of the error, so it can be annotated without affecting the original.
+func (e ConfigError) Clone() ConfigError {
+	e.Stack = cloneStack(e.Stack)
+	return e
+}
+
+// Where returns the creation site as file:line when CaptureLocation was enabled, or "" otherwise.
+func (e ConfigError) Where() string {
+	return e.Location
+}
+
 // NewConfigError creates a new ConfigError with automatic stack capture.
 func NewConfigError(key, value, reason string) ConfigError {
 	file, line, fn := captureCaller(1)
@@ -499,6 +1024,30 @@ func NewConfigError(key, value, reason string) ConfigError {
 		Function:  fn,
 		Timestamp: time.Now(),
 		Stack:     stackStrs,
+		Location:  location(file, line),
+	}
+}
+
+// NewConfigErrorf is like NewConfigError but formats the reason with fmt.Sprintf.
+func NewConfigErrorf(key, value, format string, args ...interface{}) ConfigError {
+	file, line, fn := captureCaller(1)
+	stack := captureStack(1)
+
+	var stackStrs []string
+	for _, s := range stack {
+		stackStrs = append(stackStrs, fmt.Sprintf("%s:%d %s", s.File, s.Line, s.Func))
+	}
+
+	return ConfigError{
+		Key:       key,
+		Value:     value,
+		Reason:    fmt.Sprintf(format, args...),
+		File:      file,
+		Line:      line,
+		Function:  fn,
+		Timestamp: time.Now(),
+		Stack:     stackStrs,
+		Location:  location(file, line),
 	}
 }
 
@@ -514,15 +1063,17 @@ func NewConfigError(key, value, reason string) ConfigError {
 //   - File, Line, Function: source location
 //   - Timestamp: when error occurred
 //   - Stack: call stack trace
+//   - Location: creation site, only when CaptureLocation is enabled
 type AuthError struct {
-	Operation string    `json:"operation"` // Auth operation type (login, token_verify, permission_check)
-	User      string    `json:"user"`      // User identifier
-	Reason    string    `json:"reason"`    // Error reason
-	File      string    `json:"file"`      // Source file name
-	Line      int       `json:"line"`      // Line number
-	Function  string    `json:"function"`  // Function name
-	Timestamp time.Time `json:"timestamp"` // When error occurred
-	Stack     []string  `json:"stack"`     // Call stack trace
+	Operation string    `json:"operation"`          // Auth operation type (login, token_verify, permission_check)
+	User      string    `json:"user"`               // User identifier
+	Reason    string    `json:"reason"`             // Error reason
+	File      string    `json:"file"`               // Source file name
+	Line      int       `json:"line"`               // Line number
+	Function  string    `json:"function"`           // Function name
+	Timestamp time.Time `json:"timestamp"`          // When error occurred
+	Stack     []string  `json:"stack"`              // Call stack trace
+	Location  string    `json:"location,omitempty"` // Creation site as file:line, set when CaptureLocation is on
 }
 
 func (e AuthError) Error() string {
@@ -563,6 +1114,22 @@ func (e AuthError) ToJSON() ([]byte, error) {
 	return json.Marshal(e.ToMap())
 }
 
+// LogValue implements slog.LogValuer, logging the error as a group of its fields.
+func (e AuthError) LogValue() slog.Value {
+	return logValue(e.ToMap())
+}
+
+// Clone returns a deep copy of the error, so it can be annotated without affecting the original.
+func (e AuthError) Clone() AuthError {
+	e.Stack = cloneStack(e.Stack)
+	return e
+}
+
+// Where returns the creation site as file:line when CaptureLocation was enabled, or "" otherwise.
+func (e AuthError) Where() string {
+	return e.Location
+}
+
 // NewAuthError creates a new AuthError with automatic stack capture.
 func NewAuthError(operation, user, reason string) AuthError {
 	file, line, fn := captureCaller(1)
@@ -582,6 +1149,30 @@ func NewAuthError(operation, user, reason string) AuthError {
 		Function:  fn,
 		Timestamp: time.Now(),
 		Stack:     stackStrs,
+		Location:  location(file, line),
+	}
+}
+
+// NewAuthErrorf is like NewAuthError but formats the reason with fmt.Sprintf.
+func NewAuthErrorf(operation, user, format string, args ...interface{}) AuthError {
+	file, line, fn := captureCaller(1)
+	stack := captureStack(1)
+
+	var stackStrs []string
+	for _, s := range stack {
+		stackStrs = append(stackStrs, fmt.Sprintf("%s:%d %s", s.File, s.Line, s.Func))
+	}
+
+	return AuthError{
+		Operation: operation,
+		User:      user,
+		Reason:    fmt.Sprintf(format, args...),
+		File:      file,
+		Line:      line,
+		Function:  fn,
+		Timestamp: time.Now(),
+		Stack:     stackStrs,
+		Location:  location(file, line),
 	}
 }
 
@@ -598,16 +1189,18 @@ func NewAuthError(operation, user, reason string) AuthError {
 //   - File, Line, Function: source location
 //   - Timestamp: when error occurred
 //   - Stack: call stack trace
+//   - Location: creation site, only when CaptureLocation is enabled
 type RateLimitError struct {
-	Resource   string    `json:"resource"`   // Rate-limited resource
-	Limit      int       `json:"limit"`      // Rate limit threshold
-	Current    int       `json:"current"`    // Current count
-	RetryAfter int       `json:"retryAfter"` // Seconds to wait before retry
-	File       string    `json:"file"`       // Source file name
-	Line       int       `json:"line"`       // Line number
-	Function   string    `json:"function"`   // Function name
-	Timestamp  time.Time `json:"timestamp"`  // When error occurred
-	Stack      []string  `json:"stack"`      // Call stack trace
+	Resource   string    `json:"resource"`           // Rate-limited resource
+	Limit      int       `json:"limit"`              // Rate limit threshold
+	Current    int       `json:"current"`            // Current count
+	RetryAfter int       `json:"retryAfter"`         // Seconds to wait before retry
+	File       string    `json:"file"`               // Source file name
+	Line       int       `json:"line"`               // Line number
+	Function   string    `json:"function"`           // Function name
+	Timestamp  time.Time `json:"timestamp"`          // When error occurred
+	Stack      []string  `json:"stack"`              // Call stack trace
+	Location   string    `json:"location,omitempty"` // Creation site as file:line, set when CaptureLocation is on
 }
 
 func (e RateLimitError) Error() string {
@@ -628,6 +1221,12 @@ func (e RateLimitError) Is(target error) bool {
 	return e.Resource == t.Resource
 }
 
+// Retryable always reports true: a rate-limited operation can be repeated once the
+// limit resets.
+func (e RateLimitError) Retryable() bool {
+	return true
+}
+
 // ToMap returns structured error information.
 func (e RateLimitError) ToMap() map[string]interface{} {
 	return map[string]interface{}{
@@ -649,6 +1248,22 @@ func (e RateLimitError) ToJSON() ([]byte, error) {
 	return json.Marshal(e.ToMap())
 }
 
+// LogValue implements slog.LogValuer, logging the error as a group of its fields.
+func (e RateLimitError) LogValue() slog.Value {
+	return logValue(e.ToMap())
+}
+
+// Clone returns a deep copy of the error, so it can be annotated without affecting the original.
+func (e RateLimitError) Clone() RateLimitError {
+	e.Stack = cloneStack(e.Stack)
+	return e
+}
+
+// Where returns the creation site as file:line when CaptureLocation was enabled, or "" otherwise.
+func (e RateLimitError) Where() string {
+	return e.Location
+}
+
 // NewRateLimitError creates a new RateLimitError with automatic stack capture.
 func NewRateLimitError(resource string, limit, current, retryAfter int) RateLimitError {
 	file, line, fn := captureCaller(1)
@@ -669,5 +1284,141 @@ func NewRateLimitError(resource string, limit, current, retryAfter int) RateLimi
 		Function:   fn,
 		Timestamp:  time.Now(),
 		Stack:      stackStrs,
+		Location:   location(file, line),
+	}
+}
+
+// ============================================
+// BatchDatabaseError - Partial failures of bulk operations
+// ============================================
+
+// BatchDatabaseError represents a bulk database operation where some rows failed.
+// Fields:
+//   - Operation: the database operation that failed (INSERT, UPDATE, DELETE)
+//   - Table: the table involved in the operation
+//   - RowErrors: per-row errors keyed by row index in the batch
+//   - File, Line, Function: source location
+//   - Timestamp: when error occurred
+//   - Stack: call stack trace
+//   - Location: creation site, only when CaptureLocation is enabled
+type BatchDatabaseError struct {
+	Operation string        `json:"operation"`          // Database operation (INSERT, UPDATE, DELETE)
+	Table     string        `json:"table"`              // Table name involved
+	RowErrors map[int]error `json:"rowErrors"`          // Per-row errors keyed by row index
+	File      string        `json:"file"`               // Source file name
+	Line      int           `json:"line"`               // Line number
+	Function  string        `json:"function"`           // Function name
+	Timestamp time.Time     `json:"timestamp"`          // When error occurred
+	Stack     []string      `json:"stack"`              // Call stack trace
+	Location  string        `json:"location,omitempty"` // Creation site as file:line, set when CaptureLocation is on
+}
+
+func (e BatchDatabaseError) Error() string {
+	return fmt.Sprintf("batch database error during %s on table '%s': %d row(s) failed %v (at %s:%d)", e.Operation, e.Table, len(e.RowErrors), e.FailedRows(), e.File, e.Line)
+}
+
+// FailedRows returns the indexes of the failed rows in ascending order.
+func (e BatchDatabaseError) FailedRows() []int {
+	rows := make([]int, 0, len(e.RowErrors))
+	for row := range e.RowErrors {
+		rows = append(rows, row)
+	}
+	sort.Ints(rows)
+	return rows
+}
+
+// Unwrap returns the row errors ordered by row index.
+func (e BatchDatabaseError) Unwrap() []error {
+	var errs []error
+	for _, row := range e.FailedRows() {
+		if err := e.RowErrors[row]; err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Is returns true if the target error matches based on operation and table.
+func (e BatchDatabaseError) Is(target error) bool {
+	t, ok := target.(BatchDatabaseError)
+	if !ok {
+		return false
+	}
+	return e.Operation == t.Operation && e.Table == t.Table
+}
+
+// ToMap returns structured error information.
+func (e BatchDatabaseError) ToMap() map[string]interface{} {
+	rowErrors := make(map[string]string, len(e.RowErrors))
+	for row, err := range e.RowErrors {
+		msg := ""
+		if err != nil {
+			msg = err.Error()
+		}
+		rowErrors[fmt.Sprint(row)] = msg
+	}
+	return map[string]interface{}{
+		"type":       "BatchDatabaseError",
+		"operation":  e.Operation,
+		"table":      e.Table,
+		"failedRows": e.FailedRows(),
+		"rowErrors":  rowErrors,
+		"file":       e.File,
+		"line":       e.Line,
+		"function":   e.Function,
+		"timestamp":  e.Timestamp.Format(time.RFC3339),
+		"stack":      e.Stack,
+	}
+}
+
+// ToJSON returns JSON-formatted error information.
+func (e BatchDatabaseError) ToJSON() ([]byte, error) {
+	return json.Marshal(e.ToMap())
+}
+
+// LogValue implements slog.LogValuer, logging the error as a group of its fields.
+func (e BatchDatabaseError) LogValue() slog.Value {
+	return logValue(e.ToMap())
+}
+
+// Clone returns a deep copy of the error, including its RowErrors map,
+// so it can be annotated without affecting the original. The row errors themselves are shared.
+func (e BatchDatabaseError) Clone() BatchDatabaseError {
+	e.Stack = cloneStack(e.Stack)
+	if e.RowErrors != nil {
+		rowErrors := make(map[int]error, len(e.RowErrors))
+		for row, err := range e.RowErrors {
+			rowErrors[row] = err
+		}
+		e.RowErrors = rowErrors
+	}
+	return e
+}
+
+// Where returns the creation site as file:line when CaptureLocation was enabled, or "" otherwise.
+func (e BatchDatabaseError) Where() string {
+	return e.Location
+}
+
+// NewBatchDatabaseError creates a new BatchDatabaseError with automatic stack capture.
+func NewBatchDatabaseError(operation, table string, rowErrs map[int]error) BatchDatabaseError {
+	file, line, fn := captureCaller(1)
+	stack := captureStack(1)
+
+	var stackStrs []string
+	for _, s := range stack {
+		stackStrs = append(stackStrs, fmt.Sprintf("%s:%d %s", s.File, s.Line, s.Func))
+	}
+
+	return BatchDatabaseError{
+		Operation: operation,
+		Table:     table,
+		RowErrors: rowErrs,
+		File:      file,
+		Line:      line,
+		Function:  fn,
+		Timestamp: time.Now(),
+		Stack:     stackStrs,
+		Location:  location(file, line),
 	}
 }
@@ -3,6 +3,8 @@ package errors
 import (
 	"encoding/json"
 	"errors"
+	"log/slog"
+	"net/http"
 	"strings"
 	"sync"
 	"testing"
@@ -873,3 +875,631 @@ func TestRateLimitError_ToJSON(t *testing.T) {
 		t.Errorf("Failed to parse JSON: %v", unmarshalErr)
 	}
 }
+
+// ============================================
+// BatchDatabaseError Tests
+// ============================================
+
+func TestBatchDatabaseError_Summary(t *testing.T) {
+	err := NewBatchDatabaseError("INSERT", "users", map[int]error{
+		7: errors.New("duplicate key"),
+		2: errors.New("null email"),
+		4: errors.New("check constraint"),
+	})
+
+	msg := err.Error()
+	if !strings.Contains(msg, "batch database error during INSERT on table 'users'") {
+		t.Errorf("Unexpected error message: %s", msg)
+	}
+	if !strings.Contains(msg, "3 row(s) failed [2 4 7]") {
+		t.Errorf("Expected failure count and rows in message, got: %s", msg)
+	}
+	if err.File == "" || len(err.Stack) == 0 {
+		t.Error("Expected location and stack to be captured")
+	}
+}
+
+func TestBatchDatabaseError_FailedRows(t *testing.T) {
+	err := NewBatchDatabaseError("UPDATE", "orders", map[int]error{
+		10: errors.New("a"),
+		0:  errors.New("b"),
+		3:  errors.New("c"),
+	})
+
+	rows := err.FailedRows()
+	expected := []int{0, 3, 10}
+	if len(rows) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, rows)
+	}
+	for i := range expected {
+		if rows[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, rows)
+			break
+		}
+	}
+
+	if empty := NewBatchDatabaseError("UPDATE", "orders", nil).FailedRows(); len(empty) != 0 {
+		t.Errorf("Expected no failed rows, got %v", empty)
+	}
+}
+
+func TestBatchDatabaseError_Unwrap(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+	err := NewBatchDatabaseError("INSERT", "users", map[int]error{5: second, 1: first})
+
+	if !errors.Is(err, first) || !errors.Is(err, second) {
+		t.Error("Expected errors.Is to find row errors")
+	}
+	unwrapped := err.Unwrap()
+	if len(unwrapped) != 2 || unwrapped[0] != first || unwrapped[1] != second {
+		t.Errorf("Expected row errors in row order, got %v", unwrapped)
+	}
+}
+
+func TestBatchDatabaseError_Is(t *testing.T) {
+	err1 := NewBatchDatabaseError("INSERT", "users", nil)
+	err2 := NewBatchDatabaseError("INSERT", "users", map[int]error{1: errors.New("x")})
+	err3 := NewBatchDatabaseError("INSERT", "orders", nil)
+
+	if !err1.Is(err2) {
+		t.Error("Expected same operation and table to match")
+	}
+	if err1.Is(err3) {
+		t.Error("Expected different table not to match")
+	}
+	if err1.Is(NewDatabaseError("INSERT", "users", nil)) {
+		t.Error("Expected DatabaseError not to match BatchDatabaseError")
+	}
+}
+
+func TestBatchDatabaseError_ToJSON(t *testing.T) {
+	err := NewBatchDatabaseError("INSERT", "users", map[int]error{3: errors.New("duplicate key")})
+
+	jsonBytes, jsonErr := err.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf("ToJSON failed: %v", jsonErr)
+	}
+
+	var parsed map[string]interface{}
+	if unmarshalErr := json.Unmarshal(jsonBytes, &parsed); unmarshalErr != nil {
+		t.Fatalf("Failed to parse JSON: %v", unmarshalErr)
+	}
+	if parsed["type"] != "BatchDatabaseError" {
+		t.Errorf("Expected type 'BatchDatabaseError', got %v", parsed["type"])
+	}
+	rowErrors, ok := parsed["rowErrors"].(map[string]interface{})
+	if !ok || rowErrors["3"] != "duplicate key" {
+		t.Errorf("Expected row 3 error in JSON, got %v", parsed["rowErrors"])
+	}
+}
+
+// ============================================
+// NetworkError.RetryAfter Tests
+// ============================================
+
+func TestNetworkError_RetryAfterSeconds(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Retry-After", "120")
+	err := NewNetworkErrorWithHeaders("https://api.example.com", 429, headers)
+
+	delay, ok := err.RetryAfter()
+	if !ok {
+		t.Fatal("Expected Retry-After to be parsed")
+	}
+	if delay != 120*time.Second {
+		t.Errorf("Expected 120s, got %v", delay)
+	}
+	if err.StatusCode != 429 || err.File == "" {
+		t.Errorf("Expected status and location to be populated, got %+v", err)
+	}
+}
+
+func TestNetworkError_RetryAfterHTTPDate(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Retry-After", time.Now().Add(90*time.Second).UTC().Format(http.TimeFormat))
+	err := NewNetworkErrorWithHeaders("https://api.example.com", 503, headers)
+
+	delay, ok := err.RetryAfter()
+	if !ok {
+		t.Fatal("Expected HTTP-date Retry-After to be parsed")
+	}
+	// HTTP-date has second precision, so allow a small margin.
+	if delay < 85*time.Second || delay > 90*time.Second {
+		t.Errorf("Expected roughly 90s, got %v", delay)
+	}
+}
+
+func TestNetworkError_RetryAfterPastDate(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Retry-After", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	err := NewNetworkErrorWithHeaders("https://api.example.com", 503, headers)
+
+	delay, ok := err.RetryAfter()
+	if !ok || delay != 0 {
+		t.Errorf("Expected zero delay for a past date, got %v (ok=%v)", delay, ok)
+	}
+}
+
+func TestNetworkError_RetryAfterMissing(t *testing.T) {
+	if _, ok := NewNetworkError("https://api.example.com", 503).RetryAfter(); ok {
+		t.Error("Expected no Retry-After without headers")
+	}
+
+	headers := http.Header{}
+	headers.Set("Retry-After", "soon")
+	if _, ok := NewNetworkErrorWithHeaders("https://api.example.com", 503, headers).RetryAfter(); ok {
+		t.Error("Expected malformed Retry-After to be rejected")
+	}
+
+	headers.Set("Retry-After", "-5")
+	if _, ok := NewNetworkErrorWithHeaders("https://api.example.com", 503, headers).RetryAfter(); ok {
+		t.Error("Expected negative Retry-After to be rejected")
+	}
+}
+
+func TestNetworkError_Host(t *testing.T) {
+	tests := map[string]string{
+		"https://API.Example.com/v1/users": "api.example.com",
+		"http://api.example.com:8080/x":    "api.example.com",
+		"https://[::1]:443/health":         "::1",
+	}
+	for rawURL, want := range tests {
+		host, err := NewNetworkError(rawURL, 500).Host()
+		if err != nil || host != want {
+			t.Errorf("Host(%q) = %q, %v; want %q", rawURL, host, err, want)
+		}
+	}
+}
+
+func TestNetworkError_HostInvalid(t *testing.T) {
+	for _, rawURL := range []string{"", "/relative/path", "http://%zz", "api.example.com:8080/x"} {
+		if host, err := NewNetworkError(rawURL, 500).Host(); err == nil {
+			t.Errorf("Host(%q) = %q; want error", rawURL, host)
+		}
+	}
+}
+
+func TestNetworkError_Retryable(t *testing.T) {
+	for status, want := range map[int]bool{0: true, 429: true, 503: true, 504: true, 400: false, 404: false, 501: false} {
+		if got := NewNetworkError("https://api.example.com", status).Retryable(); got != want {
+			t.Errorf("Status %d: expected Retryable %v, got %v", status, want, got)
+		}
+	}
+	if !NewNetworkTimeoutError("https://api.example.com").Retryable() {
+		t.Error("Expected timeouts to be retryable")
+	}
+	if !NewRateLimitError("api", 10, 11, 1).Retryable() {
+		t.Error("Expected rate limit errors to be retryable")
+	}
+}
+
+func TestFormattedConstructors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"validation", NewValidationErrorf("age", 1002, "must be between %d and %d", 0, 150), "must be between 0 and 150"},
+		{"database", NewDatabaseErrorf("INSERT", "users", "duplicate key %q", "alice"), `duplicate key "alice"`},
+		{"business", NewBusinessLogicErrorf("credit", "limit %d exceeded by %d", 1000, 25), "limit 1000 exceeded by 25"},
+		{"config", NewConfigErrorf("port", "70000", "must be below %d", 65536), "must be below 65536"},
+		{"auth", NewAuthErrorf("login", "bob", "%d failed attempts", 5), "5 failed attempts"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(tt.err.Error(), tt.want) {
+			t.Errorf("%s: expected %q in %q", tt.name, tt.want, tt.err.Error())
+		}
+	}
+}
+
+func TestFormattedConstructors_CallerAndWrap(t *testing.T) {
+	ve := NewValidationErrorf("f", 1, "bad %s", "value")
+	if !strings.HasSuffix(ve.File, "errors_test.go") {
+		t.Errorf("Expected the caller's file, got %s", ve.File)
+	}
+
+	sentinel := errors.New("connection reset")
+	de := NewDatabaseErrorf("SELECT", "orders", "query failed: %w", sentinel)
+	if !errors.Is(de, sentinel) {
+		t.Error("Expected the formatted cause to wrap the sentinel")
+	}
+}
+
+// ============================================
+// ValidationErrors Tests
+// ============================================
+
+func TestValidationErrors_Single(t *testing.T) {
+	ve := NewValidationError("name", "required", 1001)
+	errs := ValidationErrors{ve}
+
+	if errs.Error() != ve.Error() {
+		t.Errorf("Expected single error message to match, got %s", errs.Error())
+	}
+}
+
+func TestValidationErrors_Unwrap(t *testing.T) {
+	errs := ValidationErrors{
+		NewValidationError("name", "required", 1001),
+		NewValidationError("age", "negative", 1002),
+	}
+
+	target := ValidationError{Code: 1002}
+	if !errors.Is(errs, target) {
+		t.Error("Expected errors.Is to find code 1002")
+	}
+
+	var ve ValidationError
+	if !errors.As(errs, &ve) || ve.Field != "name" {
+		t.Errorf("Expected errors.As to find the first ValidationError, got %+v", ve)
+	}
+}
+
+func TestValidationErrors_ToJSON(t *testing.T) {
+	errs := ValidationErrors{NewValidationError("name", "required", 1001)}
+
+	jsonBytes, err := errs.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if parsed["type"] != "ValidationErrors" || parsed["count"] != float64(1) {
+		t.Errorf("Unexpected JSON: %v", parsed)
+	}
+}
+
+// ============================================
+// Clone Tests
+// ============================================
+
+func TestClone_NetworkErrorHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Retry-After", "30")
+	original := NewNetworkErrorWithHeaders("https://api.example.com", 503, headers)
+
+	clone := original.Clone()
+	clone.Headers.Set("Retry-After", "999")
+	clone.Headers.Set("X-Annotated", "yes")
+	clone.Stack[0] = "mutated"
+
+	if original.Headers.Get("Retry-After") != "30" {
+		t.Errorf("Expected original header unchanged, got %s", original.Headers.Get("Retry-After"))
+	}
+	if original.Headers.Get("X-Annotated") != "" {
+		t.Error("Expected original headers not to gain new keys")
+	}
+	if original.Stack[0] == "mutated" {
+		t.Error("Expected original stack unchanged")
+	}
+}
+
+func TestClone_BatchDatabaseErrorRows(t *testing.T) {
+	original := NewBatchDatabaseError("INSERT", "users", map[int]error{1: errors.New("dup")})
+
+	clone := original.Clone()
+	clone.RowErrors[2] = errors.New("added")
+	delete(clone.RowErrors, 1)
+
+	if len(original.RowErrors) != 1 || original.RowErrors[1] == nil {
+		t.Errorf("Expected original rows unchanged, got %v", original.RowErrors)
+	}
+}
+
+func TestClone_DatabaseErrorSharesCause(t *testing.T) {
+	cause := errors.New("cause")
+	original := NewDatabaseError("SELECT", "t", cause)
+
+	clone := original.Clone()
+	clone.Table = "other"
+
+	if clone.Cause != cause {
+		t.Error("Expected Cause to be shared")
+	}
+	if original.Table != "t" {
+		t.Error("Expected original table unchanged")
+	}
+}
+
+func TestClone_ValueTypesStack(t *testing.T) {
+	originals := []interface{ Error() string }{
+		NewValidationError("f", "m", 1),
+		NewBusinessLogicError("r", "d"),
+		NewConfigError("k", "v", "r"),
+		NewAuthError("o", "u", "r"),
+		NewRateLimitError("r", 1, 2, 3),
+	}
+	clones := []interface{ Error() string }{
+		originals[0].(ValidationError).Clone(),
+		originals[1].(BusinessLogicError).Clone(),
+		originals[2].(ConfigError).Clone(),
+		originals[3].(AuthError).Clone(),
+		originals[4].(RateLimitError).Clone(),
+	}
+
+	for i := range originals {
+		if originals[i].Error() != clones[i].Error() {
+			t.Errorf("Clone %d differs from original: %s vs %s", i, clones[i].Error(), originals[i].Error())
+		}
+	}
+
+	ve := originals[0].(ValidationError)
+	veClone := ve.Clone()
+	veClone.Stack[0] = "mutated"
+	if ve.Stack[0] == "mutated" {
+		t.Error("Expected ValidationError stack to be copied")
+	}
+}
+
+func TestClone_ValidationErrors(t *testing.T) {
+	original := ValidationErrors{NewValidationError("name", "required", 1001)}
+
+	clone := original.Clone()
+	clone[0].Message = "changed"
+
+	if original[0].Message != "required" {
+		t.Errorf("Expected original message unchanged, got %s", original[0].Message)
+	}
+	if ValidationErrors(nil).Clone() != nil {
+		t.Error("Expected nil clone of nil ValidationErrors")
+	}
+}
+
+// ============================================
+// CaptureLocation Tests
+// ============================================
+
+func TestCaptureLocation_Enabled(t *testing.T) {
+	CaptureLocation = true
+	defer func() { CaptureLocation = false }()
+
+	errs := []interface{ Where() string }{
+		NewValidationError("f", "m", 1),
+		NewDatabaseError("SELECT", "t", nil),
+		NewNetworkError("u", 500),
+		NewNetworkTimeoutError("u"),
+		NewBusinessLogicError("r", "d"),
+		NewConfigError("k", "v", "r"),
+		NewAuthError("o", "u", "r"),
+		NewRateLimitError("r", 1, 2, 3),
+		NewBatchDatabaseError("INSERT", "t", nil),
+	}
+
+	for _, err := range errs {
+		where := err.Where()
+		if !strings.HasPrefix(where, "errors_test.go:") {
+			t.Errorf("Expected %T location in errors_test.go, got %q", err, where)
+		}
+	}
+}
+
+func TestCaptureLocation_Disabled(t *testing.T) {
+	err := NewValidationError("f", "m", 1)
+
+	if err.Where() != "" {
+		t.Errorf("Expected empty location when disabled, got %q", err.Where())
+	}
+	data, _ := json.Marshal(err)
+	if strings.Contains(string(data), "location") {
+		t.Errorf("Expected location to be omitted from JSON, got %s", data)
+	}
+}
+
+// ============================================
+// ValidationTree Tests
+// ============================================
+
+func TestValidationTree_FlattenTwoLevels(t *testing.T) {
+	tree := NewValidationTree()
+	tree.AddAt("", NewValidationError("customer", "required", 1001))
+	tree.AddAt("items.10", NewValidationError("quantity", "must be positive", 1002))
+	tree.AddAt("items.0", NewValidationError("quantity", "must be positive", 1002))
+	tree.AddAt("items.0", NewValidationError("sku", "unknown", 1003))
+	tree.AddAt("items.2", NewValidationError("price", "negative", 1004))
+
+	flat := tree.Flatten()
+
+	want := []string{"customer", "items.0.quantity", "items.0.sku", "items.2.price", "items.10.quantity"}
+	if len(flat) != len(want) {
+		t.Fatalf("Expected %d leaves, got %d", len(want), len(flat))
+	}
+	for i, ve := range flat {
+		if ve.Field != want[i] {
+			t.Errorf("Expected path %s at %d, got %s", want[i], i, ve.Field)
+		}
+	}
+	if tree.Len() != 5 {
+		t.Errorf("Expected Len 5, got %d", tree.Len())
+	}
+}
+
+func TestValidationTree_FlattenDoesNotMutate(t *testing.T) {
+	var tree ValidationTree
+	tree.AddAt("address", NewValidationError("zip", "invalid", 1001))
+
+	tree.Flatten()
+
+	if tree.Children["address"].Errors[0].Field != "zip" {
+		t.Error("Expected Flatten to leave stored fields unchanged")
+	}
+}
+
+func TestValidationTree_Error(t *testing.T) {
+	tree := NewValidationTree()
+	tree.AddAt("items.0", NewValidationError("quantity", "must be positive", 1002))
+	tree.AddAt("items.1", NewValidationError("quantity", "must be positive", 1002))
+
+	var err error = tree
+	if !strings.Contains(err.Error(), "items.1.quantity: must be positive") {
+		t.Errorf("Expected flattened paths in message, got %s", err.Error())
+	}
+}
+
+func TestValidationTree_ToMapAndJSON(t *testing.T) {
+	tree := NewValidationTree()
+	tree.AddAt("", NewValidationError("customer", "required", 1001))
+	tree.AddAt("items.0", NewValidationError("sku", "unknown", 1003))
+
+	m := tree.ToMap()
+	if m["type"] != "ValidationTree" || m["count"] != 2 {
+		t.Errorf("Expected ValidationTree with 2 failures, got %v", m)
+	}
+	errs := m["errors"].([]map[string]interface{})
+	if errs[1]["field"] != "items.0.sku" {
+		t.Errorf("Expected flattened path items.0.sku, got %v", errs[1]["field"])
+	}
+
+	data, err := tree.ToJSON()
+	if err != nil || !strings.Contains(string(data), `"field":"items.0.sku"`) {
+		t.Errorf("Expected JSON with flattened path, got %s (%v)", data, err)
+	}
+}
+
+func TestValidationTree_Unwrap(t *testing.T) {
+	tree := NewValidationTree()
+	tree.AddAt("address", NewValidationError("zip", "invalid", 1001))
+
+	var ve ValidationError
+	if !errors.As(tree, &ve) || ve.Field != "address.zip" {
+		t.Errorf("Expected errors.As to reach the flattened failure, got %+v", ve)
+	}
+	if !errors.Is(tree, ValidationError{Code: 1001}) {
+		t.Error("Expected errors.Is to match the failure code")
+	}
+}
+
+// ============================================
+// LogValue Tests
+// ============================================
+
+func TestLogValue_JSONHandler(t *testing.T) {
+	var buf strings.Builder
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	logger.Error("failed", "err", NewValidationError("email", "invalid", 1001))
+
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(buf.String()), &record); err != nil {
+		t.Fatalf("Expected JSON log line, got %v: %s", err, buf.String())
+	}
+	group, ok := record["err"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected err to be logged as a group, got %v", record["err"])
+	}
+	if group["type"] != "ValidationError" || group["field"] != "email" || group["code"] != float64(1001) {
+		t.Errorf("Unexpected grouped attributes: %v", group)
+	}
+	if _, ok := group["stack"]; ok {
+		t.Error("Expected stack to be omitted from logs")
+	}
+}
+
+func TestLogValue_AllTypes(t *testing.T) {
+	tree := NewValidationTree()
+	tree.AddAt("items.0", NewValidationError("sku", "unknown", 1003))
+
+	tests := []struct {
+		value slog.LogValuer
+		key   string
+		want  interface{}
+	}{
+		{NewDatabaseError("SELECT", "users", nil), "table", "users"},
+		{NewNetworkError("https://api.example.com", 503), "statusCode", int64(503)},
+		{NewBusinessLogicError("credit_limit", "exceeded"), "rule", "credit_limit"},
+		{NewConfigError("db.host", "", "missing"), "key", "db.host"},
+		{NewAuthError("login", "bob", "bad password"), "user", "bob"},
+		{NewRateLimitError("api", 10, 11, 30), "resource", "api"},
+		{NewBatchDatabaseError("INSERT", "orders", nil), "table", "orders"},
+		{ValidationErrors{NewValidationError("a", "b", 1)}, "count", int64(1)},
+		{tree, "count", int64(1)},
+	}
+
+	for _, tt := range tests {
+		value := tt.value.LogValue()
+		if value.Kind() != slog.KindGroup {
+			t.Errorf("Expected group for %T, got %v", tt.value, value.Kind())
+			continue
+		}
+
+		attrs := value.Group()
+		if attrs[0].Key != "type" {
+			t.Errorf("Expected type first for %T, got %s", tt.value, attrs[0].Key)
+		}
+		var found bool
+		for _, attr := range attrs {
+			if attr.Key == tt.key {
+				found = true
+				if got := attr.Value.Any(); got != tt.want {
+					t.Errorf("Expected %s=%v for %T, got %v", tt.key, tt.want, tt.value, got)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("Expected attribute %s for %T", tt.key, tt.value)
+		}
+	}
+}
+
+// ============================================
+// BuiltinKind Tests
+// ============================================
+
+func TestBuiltinKind_AllTypes(t *testing.T) {
+	ve := NewValidationError("email", "invalid", 1)
+	de := NewDatabaseError("SELECT", "users", nil)
+	ne := NewNetworkError("https://api.example.com", 503)
+	be := NewBusinessLogicError("credit_limit", "exceeded")
+	ce := NewConfigError("db.host", "", "missing")
+	ae := NewAuthError("login", "bob", "bad password")
+	re := NewRateLimitError("api", 10, 11, 30)
+	bde := NewBatchDatabaseError("INSERT", "orders", nil)
+	ves := ValidationErrors{ve}
+
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{ve, "validation"},
+		{&ve, "validation"},
+		{ves, "validation"},
+		{&ves, "validation"},
+		{NewValidationTree(), "validation"},
+		{de, "database"},
+		{&de, "database"},
+		{bde, "database"},
+		{&bde, "database"},
+		{ne, "network"},
+		{&ne, "network"},
+		{be, "business"},
+		{&be, "business"},
+		{ce, "config"},
+		{&ce, "config"},
+		{ae, "auth"},
+		{&ae, "auth"},
+		{re, "ratelimit"},
+		{&re, "ratelimit"},
+	}
+
+	for _, tt := range tests {
+		if got := BuiltinKind(tt.value); got != tt.want {
+			t.Errorf("BuiltinKind(%T) = %q, want %q", tt.value, got, tt.want)
+		}
+		if !IsBuiltin(tt.value) {
+			t.Errorf("Expected IsBuiltin(%T) to be true", tt.value)
+		}
+	}
+}
+
+func TestBuiltinKind_ForeignTypes(t *testing.T) {
+	for _, v := range []interface{}{nil, errors.New("plain"), "string panic", 42} {
+		if got := BuiltinKind(v); got != "" {
+			t.Errorf("BuiltinKind(%T) = %q, want empty", v, got)
+		}
+		if IsBuiltin(v) {
+			t.Errorf("Expected IsBuiltin(%T) to be false", v)
+		}
+	}
+}
@@ -220,6 +220,47 @@ func demo5_NewErrorTypes() {
 			err.Resource, err.Limit, err.Current, err.RetryAfter)
 	})
 	tb.Finally(func() {})
+
+	// ValidationErrors
+	fmt.Println("\n--- ValidationErrors ---")
+	tb = gotrycatch.Try(func() {
+		gotrycatch.NewValidator().
+			Require(false, "name", "name cannot be empty", 1001).
+			Require(false, "age", "age must be positive", 1002).
+			Check()
+	})
+	tb = gotrycatch.Catch[trycatcherrors.ValidationErrors](tb, func(err trycatcherrors.ValidationErrors) {
+		fmt.Printf("✓ ValidationErrors: %d failures on fields %v\n", len(err), err.Fields())
+	})
+	tb.Finally(func() {})
+
+	// ValidationTree
+	fmt.Println("\n--- ValidationTree ---")
+	tb = gotrycatch.Try(func() {
+		tree := trycatcherrors.NewValidationTree()
+		tree.AddAt("items.0", trycatcherrors.NewValidationError("quantity", "must be positive", 1002))
+		tree.AddAt("items.2", trycatcherrors.NewValidationError("price", "cannot be negative", 1004))
+		gotrycatch.Throw(tree)
+	})
+	tb = gotrycatch.Catch[*trycatcherrors.ValidationTree](tb, func(err *trycatcherrors.ValidationTree) {
+		for _, ve := range err.Flatten() {
+			fmt.Printf("✓ ValidationTree: %s - %s\n", ve.Field, ve.Message)
+		}
+	})
+	tb.Finally(func() {})
+
+	// BatchDatabaseError
+	fmt.Println("\n--- BatchDatabaseError ---")
+	tb = gotrycatch.Try(func() {
+		gotrycatch.Throw(trycatcherrors.NewBatchDatabaseError("INSERT", "orders", map[int]error{
+			3:  errors.New("duplicate key"),
+			17: errors.New("foreign key violation"),
+		}))
+	})
+	tb = gotrycatch.Catch[trycatcherrors.BatchDatabaseError](tb, func(err trycatcherrors.BatchDatabaseError) {
+		fmt.Printf("✓ BatchDatabaseError: %s on %s, failed rows %v\n", err.Operation, err.Table, err.FailedRows())
+	})
+	tb.Finally(func() {})
 }
 
 func demo6_DebugMode() {
@@ -416,4 +457,5 @@ func main() {
 	fmt.Println("  6. TryWithResult: 支持返回值, OnSuccess, OnError, OrElse, OrElseGet")
 	fmt.Println("  7. 断言辅助: Assert(), AssertNoError()")
 	fmt.Println("  8. 新增错误类型: ConfigError, AuthError, RateLimitError")
+	fmt.Println("  9. 多错误类型: ValidationErrors, ValidationTree, BatchDatabaseError")
 }
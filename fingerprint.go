@@ -0,0 +1,94 @@
+package gotrycatch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Fingerprint - Stable identity for deduplication
+// ============================================
+
+// Fingerprinter can be implemented by custom error types to supply their own
+// discriminators for Fingerprint.
+type Fingerprinter interface {
+	FingerprintKey() string
+}
+
+// Fingerprint returns a stable hash identifying "the same kind of error".
+// It combines the type name with type-specific discriminators and ignores volatile
+// details such as location, timestamps, stacks and free-form messages of built-in types:
+//   - ValidationError: field and code
+//   - DatabaseError, BatchDatabaseError: operation and table
+//   - NetworkError: host, status code and timeout flag
+//   - BusinessLogicError: rule
+//   - ConfigError: key
+//   - AuthError: operation
+//   - RateLimitError: resource
+//
+// Pointers to built-in types fingerprint like the values they point to.
+// Other values are discriminated by their message. Returns an empty string for nil.
+func Fingerprint(err interface{}) string {
+	if err == nil {
+		return ""
+	}
+
+	err = derefBuiltin(err)
+	sum := sha256.Sum256([]byte(TypeName(err) + "|" + fingerprintKey(err)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// derefBuiltin returns the value a non-nil pointer to a built-in error type points to.
+// Other values are returned unchanged.
+func derefBuiltin(err interface{}) interface{} {
+	if !trycatcherrors.IsBuiltin(err) {
+		return err
+	}
+	if v := reflect.ValueOf(err); v.Kind() == reflect.Ptr && !v.IsNil() {
+		return v.Elem().Interface()
+	}
+	return err
+}
+
+// fingerprintKey returns the discriminating part of the fingerprint input.
+func fingerprintKey(err interface{}) string {
+	switch e := err.(type) {
+	case Fingerprinter:
+		return e.FingerprintKey()
+	case trycatcherrors.ValidationError:
+		return fmt.Sprintf("%s|%d", e.Field, e.Code)
+	case trycatcherrors.DatabaseError:
+		return e.Operation + "|" + e.Table
+	case trycatcherrors.BatchDatabaseError:
+		return e.Operation + "|" + e.Table
+	case trycatcherrors.NetworkError:
+		return fmt.Sprintf("%s|%d|%v", networkHost(e), e.StatusCode, e.Timeout)
+	case trycatcherrors.BusinessLogicError:
+		return e.Rule
+	case trycatcherrors.ConfigError:
+		return e.Key
+	case trycatcherrors.AuthError:
+		return e.Operation
+	case trycatcherrors.RateLimitError:
+		return e.Resource
+	case *ContextError:
+		return e.Message
+	case error:
+		return e.Error()
+	default:
+		return fmt.Sprint(e)
+	}
+}
+
+// networkHost returns the host of a NetworkError, falling back to the raw URL
+// when it cannot be parsed.
+func networkHost(e trycatcherrors.NetworkError) string {
+	if host, err := e.Host(); err == nil {
+		return host
+	}
+	return e.URL
+}
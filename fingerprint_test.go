@@ -0,0 +1,102 @@
+package gotrycatch
+
+import (
+	"errors"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Fingerprint 测试
+// ============================================
+
+func TestFingerprint_SameLogicalError(t *testing.T) {
+	pairs := []struct {
+		name string
+		a, b interface{}
+	}{
+		{"ValidationError",
+			trycatcherrors.NewValidationError("email", "bad format", 1001),
+			trycatcherrors.NewValidationError("email", "missing @", 1001)},
+		{"DatabaseError",
+			trycatcherrors.NewDatabaseError("INSERT", "users", errors.New("dup 1")),
+			trycatcherrors.NewDatabaseError("INSERT", "users", errors.New("dup 2"))},
+		{"NetworkError",
+			trycatcherrors.NewNetworkError("https://api.example.com/users/1", 503),
+			trycatcherrors.NewNetworkError("https://API.example.com/orders?id=2", 503)},
+		{"BusinessLogicError",
+			trycatcherrors.NewBusinessLogicError("min_balance", "balance 5"),
+			trycatcherrors.NewBusinessLogicError("min_balance", "balance 7")},
+	}
+
+	for _, p := range pairs {
+		fa, fb := Fingerprint(p.a), Fingerprint(p.b)
+		if fa == "" {
+			t.Errorf("%s: expected non-empty fingerprint", p.name)
+		}
+		if fa != fb {
+			t.Errorf("%s: expected equal fingerprints, got %s and %s", p.name, fa, fb)
+		}
+	}
+}
+
+func TestFingerprint_PointerMatchesValue(t *testing.T) {
+	db := trycatcherrors.NewDatabaseError("INSERT", "users", errors.New("dup 1"))
+	other := trycatcherrors.NewDatabaseError("INSERT", "users", errors.New("dup 2"))
+	cfg := trycatcherrors.NewConfigError("db.host", "", "missing")
+
+	if Fingerprint(&db) != Fingerprint(db) {
+		t.Errorf("Expected *DatabaseError to fingerprint like its value")
+	}
+	if Fingerprint(&db) != Fingerprint(&other) {
+		t.Errorf("Expected pointers created at different lines to fingerprint alike")
+	}
+	if Fingerprint(&cfg) != Fingerprint(cfg) {
+		t.Errorf("Expected *ConfigError to fingerprint like its value")
+	}
+}
+
+func TestFingerprint_DifferentLogicalError(t *testing.T) {
+	pairs := []struct {
+		name string
+		a, b interface{}
+	}{
+		{"rule",
+			trycatcherrors.NewBusinessLogicError("min_balance", "d"),
+			trycatcherrors.NewBusinessLogicError("max_withdrawal", "d")},
+		{"field",
+			trycatcherrors.NewValidationError("email", "m", 1001),
+			trycatcherrors.NewValidationError("name", "m", 1001)},
+		{"host",
+			trycatcherrors.NewNetworkError("https://a.example.com", 503),
+			trycatcherrors.NewNetworkError("https://b.example.com", 503)},
+		{"status",
+			trycatcherrors.NewNetworkError("https://a.example.com", 503),
+			trycatcherrors.NewNetworkError("https://a.example.com", 502)},
+		{"type",
+			trycatcherrors.NewConfigError("x", "v", "r"),
+			trycatcherrors.NewRateLimitError("x", 1, 2, 3)},
+	}
+
+	for _, p := range pairs {
+		if Fingerprint(p.a) == Fingerprint(p.b) {
+			t.Errorf("%s: expected different fingerprints", p.name)
+		}
+	}
+}
+
+func TestFingerprint_OtherValues(t *testing.T) {
+	if Fingerprint(nil) != "" {
+		t.Error("Expected empty fingerprint for nil")
+	}
+	if Fingerprint("timeout") != Fingerprint("timeout") {
+		t.Error("Expected equal strings to share a fingerprint")
+	}
+	if Fingerprint("timeout") == Fingerprint(errors.New("timeout")) {
+		t.Error("Expected different types to differ even with the same message")
+	}
+	if len(Fingerprint(42)) != 16 {
+		t.Errorf("Expected 16 hex characters, got %q", Fingerprint(42))
+	}
+}
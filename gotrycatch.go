@@ -24,8 +24,12 @@ package gotrycatch
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"runtime"
+	"time"
 )
 
 // Version is the current version of the gotrycatch library.
@@ -54,8 +58,17 @@ func debugLog(format string, args ...interface{}) {
 
 // TryBlock represents a try block that can catch and handle panics
 type TryBlock struct {
-	err     interface{}
-	handled bool
+	err          interface{}
+	handled      bool
+	duration     time.Duration
+	failedStep   int // 1-based index of the failed TrySeq step; 0 if none
+	values       map[string]interface{}
+	stack        []uintptr         // panic stack, recorded when CaptureStack is enabled
+	goroutineID  uint64            // panicking goroutine, recorded when CaptureStack is enabled
+	handledByAny bool              // handled by CatchAny, tracked for DebugOrdering
+	checkpoint   *checkpoint       // steps of a failed TryCheckpoint run, for Resume
+	attempts     []string          // typed catches tried while unhandled, when RecordHistory is on
+	snapshot     map[string]string // environment at recover time, when SnapshotFunc is set
 }
 
 // GetError returns the captured error, or nil if no error occurred.
@@ -85,7 +98,26 @@ func (tb *TryBlock) IsHandled() bool {
 	return tb.handled
 }
 
+// Take returns the captured panic value and clears it from the block, marking it
+// handled, for callers that want to deal with the error themselves. Afterwards the
+// block no longer holds an error, so Finally will not re-panic.
+// Returns nil, leaving the block unchanged, if nothing was captured or the TryBlock
+// itself is nil.
+func (tb *TryBlock) Take() interface{} {
+	if tb == nil || tb.err == nil {
+		return nil
+	}
+	err := tb.err
+	debugLog("Take: consumed %T", err)
+	tb.err, tb.handled = nil, true
+	return err
+}
+
+// TryBlock implements fmt.Stringer so %v of a block is useful in logs and test failures.
+var _ fmt.Stringer = (*TryBlock)(nil)
+
 // String returns a friendly string representation of the TryBlock for debugging and logging.
+// It reports the error's type and message and whether it was handled.
 func (tb *TryBlock) String() string {
 	if tb == nil {
 		return "TryBlock{nil}"
@@ -93,7 +125,71 @@ func (tb *TryBlock) String() string {
 	if tb.err == nil {
 		return "TryBlock{err: nil, handled: false}"
 	}
-	return fmt.Sprintf("TryBlock{err: %T(%v), handled: %v}", tb.err, tb.err, tb.handled)
+	return fmt.Sprintf("TryBlock{err: %T(%s), handled: %v}", tb.err, Describe(tb.err), tb.handled)
+}
+
+var _ error = (*TryBlock)(nil)
+
+// Error implements the error interface so a TryBlock can flow through error-returning
+// APIs. It returns the message of the unhandled error, or "" if the block is clean or
+// handled. Because a non-nil *TryBlock is never a nil error, and %v prints the block's
+// String, return ErrOrNil instead of the block itself.
+func (tb *TryBlock) Error() string {
+	if tb == nil || tb.err == nil || tb.handled {
+		return ""
+	}
+	return errorMessage(tb.err)
+}
+
+// Unwrap returns the captured value if it is an error, so errors.Is and errors.As
+// see through a TryBlock used as an error. Like Error, it returns nil once the block
+// is handled.
+func (tb *TryBlock) Unwrap() error {
+	if tb == nil || tb.handled {
+		return nil
+	}
+	err, _ := tb.err.(error)
+	return err
+}
+
+// ErrOrNil returns the unhandled error of the block, and nil if it is clean or handled,
+// so that `return tb.ErrOrNil()` works as expected. The returned error prints as the
+// captured value's message and unwraps to it, so it reads naturally when logged or
+// wrapped with %w. Non-error panic values are carried as well.
+func (tb *TryBlock) ErrOrNil() error {
+	if tb == nil || tb.err == nil || tb.handled {
+		return nil
+	}
+	return capturedError{value: tb.err}
+}
+
+// capturedError is the error returned by ErrOrNil. Unlike *TryBlock, whose %v prints
+// its debug String, it formats as the message of the captured value.
+type capturedError struct {
+	value interface{}
+}
+
+func (e capturedError) Error() string {
+	return errorMessage(e.value)
+}
+
+// Unwrap returns the captured value if it is an error.
+func (e capturedError) Unwrap() error {
+	err, _ := e.value.(error)
+	return err
+}
+
+// Format keeps %v and %s printing String rather than Error, which fmt would
+// otherwise prefer now that TryBlock implements error.
+func (tb *TryBlock) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		io.WriteString(f, tb.String())
+	case 'q':
+		fmt.Fprintf(f, "%q", tb.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, tb.String())
+	}
 }
 
 // GetErrorType returns the type name of the captured error (e.g., "errors.ValidationError").
@@ -103,28 +199,99 @@ func (tb *TryBlock) GetErrorType() string {
 	if tb == nil || tb.err == nil {
 		return ""
 	}
-	return fmt.Sprintf("%T", tb.err)
+	return TypeName(tb.err)
+}
+
+// TypeName returns the type name of a panic value as printed by %T (e.g., "errors.ValidationError").
+// Returns an empty string for nil.
+func TypeName(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// Set attaches a value to the TryBlock under key, so a Catch handler can pass data
+// to a later Finally or to code inspecting the block. The map is allocated on first use.
+// Returns the same TryBlock for method chaining; a nil TryBlock is returned unchanged.
+func (tb *TryBlock) Set(key string, val interface{}) *TryBlock {
+	if tb == nil {
+		return nil
+	}
+	if tb.values == nil {
+		tb.values = make(map[string]interface{})
+	}
+	tb.values[key] = val
+	return tb
+}
+
+// Get returns the value attached under key and whether it was present.
+// Returns (nil, false) if the TryBlock itself is nil.
+func (tb *TryBlock) Get(key string) (interface{}, bool) {
+	if tb == nil {
+		return nil, false
+	}
+	val, ok := tb.values[key]
+	return val, ok
 }
 
 // Try executes the given function and captures any panic that occurs.
 // It returns a TryBlock that can be used with Catch and Finally methods.
 func Try(fn func()) *TryBlock {
 	tb := &TryBlock{}
+	if TrackInFlight {
+		defer trackStart(1)()
+	}
 
 	func() {
+		completed := false
 		defer func() {
-			if r := recover(); r != nil {
+			if r := normalizePanic(recover(), completed); r != nil {
 				tb.err = r
+				if CaptureStack {
+					tb.stack = capturePanicStack()
+					tb.goroutineID = goroutineID()
+				}
+				if SnapshotFunc != nil {
+					tb.snapshot = takeSnapshot()
+				}
 				debugLog("Try: captured panic of type %T: %v", r, r)
 			}
 		}()
 
 		fn()
+		completed = true
 	}()
 
 	return tb
 }
 
+// NilPanicError is captured in place of the value when code calls panic(nil), so the
+// panic can be caught deterministically with Catch[NilPanicError] on every Go version.
+type NilPanicError struct{}
+
+func (NilPanicError) Error() string {
+	return "panic called with nil argument"
+}
+
+// normalizePanic maps a recovered value to what Try stores. completed reports whether
+// the protected function returned normally; a nil value from a function that did not
+// complete means panic(nil) under GODEBUG=panicnil=1. Both that case and the
+// *runtime.PanicNilError produced by Go 1.21+ become NilPanicError.
+// Other values pass through any Translator registered for their type.
+func normalizePanic(r interface{}, completed bool) interface{} {
+	if r == nil {
+		if completed {
+			return nil
+		}
+		return NilPanicError{}
+	}
+	if _, ok := r.(*runtime.PanicNilError); ok {
+		return NilPanicError{}
+	}
+	return translatePanic(r)
+}
+
 // Catch handles panics of the specified type T.
 // If the panic value can be cast to type T, the handler function is called.
 // Returns the same TryBlock to allow chaining multiple Catch calls.
@@ -139,6 +306,7 @@ func Catch[T any](tb *TryBlock, handler func(T)) *TryBlock {
 		return tb
 	}
 
+	noteCatch[T](tb, "Catch")
 	if tb.err != nil && !tb.handled {
 		if err, ok := tb.err.(T); ok {
 			debugLog("Catch: type %T matched, calling handler", tb.err)
@@ -165,6 +333,7 @@ func CatchWithReturn[T any](tb *TryBlock, handler func(T) interface{}) (interfac
 		return nil, tb
 	}
 
+	noteCatch[T](tb, "CatchWithReturn")
 	if tb.err != nil && !tb.handled {
 		if err, ok := tb.err.(T); ok {
 			debugLog("CatchWithReturn: type %T matched, calling handler", tb.err)
@@ -178,6 +347,66 @@ func CatchWithReturn[T any](tb *TryBlock, handler func(T) interface{}) (interfac
 	return nil, tb
 }
 
+// CatchReturnErr handles panics of type T with a handler that returns both a result and
+// an error, bridging rich handlers to error-returning call sites. If the handler returns
+// a nil error, the block is marked handled. Otherwise the returned error replaces the
+// block's error and the block stays unhandled, so it propagates to later catches or
+// Finally. The handler's results are returned along with the TryBlock; if the handler
+// did not run, the zero value of R and a nil error are returned.
+func CatchReturnErr[T any, R any](tb *TryBlock, handler func(T) (R, error)) (R, error, *TryBlock) {
+	var zero R
+	if tb == nil {
+		debugLog("CatchReturnErr: TryBlock is nil, returning empty TryBlock")
+		return zero, nil, &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchReturnErr: handler is nil, returning TryBlock unchanged")
+		return zero, nil, tb
+	}
+
+	noteCatch[T](tb, "CatchReturnErr")
+	if tb.err != nil && !tb.handled {
+		if err, ok := tb.err.(T); ok {
+			debugLog("CatchReturnErr: type %T matched, calling handler", tb.err)
+			result, handlerErr := handler(err)
+			if handlerErr != nil {
+				debugLog("CatchReturnErr: handler returned %T, storing it unhandled", handlerErr)
+				tb.err = handlerErr
+				return result, handlerErr, tb
+			}
+			tb.handled = true
+			return result, nil, tb
+		}
+		debugLog("CatchReturnErr: type %T does not match target type %T", tb.err, *new(T))
+	}
+	return zero, nil, tb
+}
+
+// CatchAnyWithReturn handles any unhandled panic like CatchAny and allows the handler to
+// return a value, e.g. a fallback result for a final catch-all. The handler's return value
+// is returned along with the TryBlock; nil is returned if the handler did not run.
+func CatchAnyWithReturn(tb *TryBlock, handler func(interface{}) interface{}) (interface{}, *TryBlock) {
+	if tb == nil {
+		debugLog("CatchAnyWithReturn: TryBlock is nil, returning empty TryBlock")
+		return nil, &TryBlock{}
+	}
+
+	if handler == nil {
+		debugLog("CatchAnyWithReturn: handler is nil, returning TryBlock unchanged")
+		return nil, tb
+	}
+
+	if tb.err != nil && !tb.handled {
+		debugLog("CatchAnyWithReturn: handling error of type %T", tb.err)
+		result := handler(tb.err)
+		tb.handled = true
+		tb.handledByAny = true
+		return result, tb
+	}
+	return nil, tb
+}
+
 // CatchAny handles any unhandled panic, regardless of type.
 // This method should typically be called last in a chain of Catch calls.
 func (tb *TryBlock) CatchAny(handler func(interface{})) *TryBlock {
@@ -195,12 +424,18 @@ func (tb *TryBlock) CatchAny(handler func(interface{})) *TryBlock {
 		debugLog("CatchAny: handling error of type %T", tb.err)
 		handler(tb.err)
 		tb.handled = true
+		tb.handledByAny = true
 	}
 	return tb
 }
 
 // Finally executes the given function regardless of whether a panic occurred.
-// If there was an unhandled panic, it will be re-thrown after the finally block executes.
+// If there was an unhandled panic, it will be re-thrown after the finally block executes,
+// or passed to UnhandledPolicy if one is set.
+// Handled errors at or above RethrowAtOrAbove are re-thrown as well.
+//
+// If fn itself panics while an error is being re-thrown, Go's defer semantics make
+// fn's panic replace the original error. Use SafeFinally to keep both.
 func (tb *TryBlock) Finally(fn func()) {
 	if fn == nil {
 		debugLog("Finally: handler is nil, returning without action")
@@ -213,15 +448,119 @@ func (tb *TryBlock) Finally(fn func()) {
 	}
 
 	defer fn()
-	if tb.err != nil && !tb.handled {
+	if shouldRethrow(tb.err, tb.handled) {
 		debugLog("Finally: re-throwing unhandled error of type %T: %v", tb.err, tb.err)
-		panic(tb.err) // Re-throw unhandled exception
+		rethrow(wrapRethrow(tb.err, tb.StackTrace())) // Re-throw unhandled exception
+	}
+}
+
+// SafeFinally is like Finally, but recovers a panic raised by fn itself instead of
+// letting it mask the block's error. If the block has an error that would be re-thrown
+// and fn panics, both values are re-thrown together as a MultiError (block error first).
+// If only fn panics, its panic is re-raised unchanged. A nil fn is treated as a no-op.
+func (tb *TryBlock) SafeFinally(fn func()) {
+	var finallyErr interface{}
+	if fn != nil {
+		finallyErr = recoverFrom(fn)
+	}
+
+	pending := tb != nil && shouldRethrow(tb.err, tb.handled)
+	switch {
+	case finallyErr != nil && pending:
+		debugLog("SafeFinally: finally panicked with %T while re-throwing %T, merging", finallyErr, tb.err)
+		rethrow(wrapRethrow(mergeRethrow(tb.err, finallyErr), tb.StackTrace()))
+	case finallyErr != nil:
+		debugLog("SafeFinally: finally panicked with %T", finallyErr)
+		panic(finallyErr)
+	case pending:
+		debugLog("SafeFinally: re-throwing unhandled error of type %T: %v", tb.err, tb.err)
+		rethrow(wrapRethrow(tb.err, tb.StackTrace()))
+	}
+}
+
+// recoverFrom runs fn and returns the value it panicked with, or nil.
+func recoverFrom(fn func()) (recovered interface{}) {
+	completed := false
+	defer func() {
+		recovered = normalizePanic(recover(), completed)
+	}()
+	fn()
+	completed = true
+	return nil
+}
+
+// UnhandledPolicy, when set, is called by Finally instead of re-panicking with an
+// unhandled error. It centralizes the decision for a whole program, e.g. to log and
+// swallow, or to exit the process. When nil, Finally re-panics.
+var UnhandledPolicy func(err interface{})
+
+// FlattenRethrows keeps re-thrown values flat when errors bubble through several
+// Finally layers. Plain Finally re-throws the original value unchanged, so nesting only
+// arises from the two wrapping mechanisms, and FlattenRethrows handles both:
+//   - SafeFinally merging into a MultiError that is itself a MultiError yields one
+//     flat MultiError rather than a nested one.
+//   - With WrapRethrows, outer layers re-throw an existing *RethrowError unchanged
+//     instead of wrapping it again.
+//
+// It is off by default.
+var FlattenRethrows = false
+
+// mergeRethrow combines the values of a re-throw into a MultiError, flattening nested
+// MultiErrors when FlattenRethrows is on.
+func mergeRethrow(values ...interface{}) MultiError {
+	if !FlattenRethrows {
+		return MultiError{Errors: values}
+	}
+	var flat []interface{}
+	for _, v := range values {
+		if inner, ok := v.(MultiError); ok {
+			flat = append(flat, inner.Errors...)
+		} else {
+			flat = append(flat, v)
+		}
 	}
+	return MultiError{Errors: flat}
 }
 
+// rethrow re-raises an unhandled error, or passes it to UnhandledPolicy if one is set.
+func rethrow(err interface{}) {
+	if UnhandledPolicy != nil {
+		debugLog("Finally: passing error of type %T to UnhandledPolicy", err)
+		UnhandledPolicy(err)
+		return
+	}
+	panic(err)
+}
+
+// BeforeThrow, when set, is invoked by Throw with the value about to be thrown.
+// It may return a transformed value to throw instead, or nil to veto the throw,
+// in which case Throw returns without panicking.
+// It is intended for program-wide policies such as sanitizing thrown errors.
+var BeforeThrow func(err interface{}) interface{}
+
+// AnnotateStringThrows makes Throw append the caller's location to string values, so
+// bare string panics say where they came from while remaining catchable as strings:
+// Throw("not found") panics with "not found (at handler.go:42)". The location is the
+// first caller outside this package's throw helpers, e.g. the caller of Assert.
+// It is off by default.
+var AnnotateStringThrows = false
+
 // Throw creates a panic with the given value.
 // This is a convenience function to make code more readable.
+// If BeforeThrow is set, the value is passed through it first.
 func Throw(err interface{}) {
+	if BeforeThrow != nil {
+		err = BeforeThrow(err)
+		if err == nil {
+			debugLog("Throw: value vetoed by BeforeThrow")
+			return
+		}
+	}
+	if s, ok := err.(string); ok && AnnotateStringThrows {
+		if loc := throwCaller(); loc != "" {
+			err = s + " (at " + loc + ")"
+		}
+	}
 	panic(err)
 }
 
@@ -240,6 +579,34 @@ func AssertNoError(err error, msg string) {
 	}
 }
 
+// AssertionError is thrown by Assertf when an assertion fails.
+type AssertionError struct {
+	Message  string // Formatted assertion message
+	Location string // Caller of Assertf as file:line
+}
+
+func (e *AssertionError) Error() string {
+	if e.Location == "" {
+		return "assertion failed: " + e.Message
+	}
+	return fmt.Sprintf("assertion failed: %s (at %s)", e.Message, e.Location)
+}
+
+// Assertf throws an *AssertionError with a formatted message and the caller's location
+// if the condition is false. Unlike Assert, the thrown value is always an *AssertionError,
+// so assertion failures can be caught uniformly with Catch[*AssertionError].
+func Assertf(condition bool, format string, args ...interface{}) {
+	if condition {
+		return
+	}
+
+	err := &AssertionError{Message: fmt.Sprintf(format, args...)}
+	if _, file, line, ok := runtime.Caller(1); ok {
+		err.Location = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	}
+	Throw(err)
+}
+
 // ============================================
 // TryWithResult - Try with return value support
 // ============================================
@@ -299,16 +666,21 @@ func (tb *TryBlockWithResult[T]) String() string {
 // TryWithResult executes the given function and captures both the return value and any panic.
 func TryWithResult[T any](fn func() T) *TryBlockWithResult[T] {
 	tb := &TryBlockWithResult[T]{}
+	if TrackInFlight {
+		defer trackStart(1)()
+	}
 
 	func() {
+		completed := false
 		defer func() {
-			if r := recover(); r != nil {
+			if r := normalizePanic(recover(), completed); r != nil {
 				tb.err = r
 				debugLog("TryWithResult: captured panic of type %T: %v", r, r)
 			}
 		}()
 
 		tb.result = fn()
+		completed = true
 	}()
 
 	return tb
@@ -361,7 +733,9 @@ func CatchAnyWithResult[T any](tb *TryBlockWithResult[T], handler func(interface
 }
 
 // Finally executes the cleanup function regardless of whether a panic occurred.
-// If there was an unhandled panic, it will be re-thrown after fn executes.
+// If there was an unhandled panic, it will be re-thrown after fn executes,
+// or passed to UnhandledPolicy if one is set.
+// Handled errors at or above RethrowAtOrAbove are re-thrown as well.
 // Returns the result value (or zero value if nil).
 func (tb *TryBlockWithResult[T]) Finally(fn func()) T {
 	if fn == nil {
@@ -380,9 +754,9 @@ func (tb *TryBlockWithResult[T]) Finally(fn func()) T {
 	}
 
 	defer fn()
-	if tb.err != nil && !tb.handled {
+	if shouldRethrow(tb.err, tb.handled) {
 		debugLog("Finally: re-throwing unhandled error of type %T: %v", tb.err, tb.err)
-		panic(tb.err)
+		rethrow(wrapRethrow(tb.err, nil))
 	}
 	return tb.result
 }
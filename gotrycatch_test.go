@@ -2,6 +2,8 @@ package gotrycatch
 
 import (
 	"errors"
+	"fmt"
+	"runtime"
 	"testing"
 
 	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
@@ -655,6 +657,30 @@ func TestString(t *testing.T) {
 	}
 }
 
+func TestString_Handled(t *testing.T) {
+	tb := Try(func() {
+		panic(errors.New("disk full"))
+	})
+	tb = tb.CatchAny(func(err interface{}) {})
+
+	expected := "TryBlock{err: *errors.errorString(disk full), handled: true}"
+	if tb.String() != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, tb.String())
+	}
+}
+
+func TestString_FormatVerb(t *testing.T) {
+	clean := Try(func() {})
+	if got := fmt.Sprintf("%v", clean); got != "TryBlock{err: nil, handled: false}" {
+		t.Errorf("Expected %%v to use String for clean block, got '%s'", got)
+	}
+
+	failed := Try(func() { panic(42) })
+	if got := fmt.Sprintf("%v", failed); got != "TryBlock{err: int(42), handled: false}" {
+		t.Errorf("Expected %%v to use String for failed block, got '%s'", got)
+	}
+}
+
 func TestGetErrorType(t *testing.T) {
 	// 无错误
 	tb := Try(func() {})
@@ -1131,8 +1157,8 @@ func TestEmptyStringPanic(t *testing.T) {
 }
 
 func TestNilPanicValue(t *testing.T) {
-	// Note: panic(nil) in Go has special behavior - recover() returns a runtime.nilPanicError
-	// We still detect it as having an error (HasError = true)
+	// Note: panic(nil) in Go has special behavior - recover() returns a *runtime.PanicNilError
+	// Try normalizes it to NilPanicError, so it is still detected (HasError = true)
 	tb := Try(func() {
 		panic(nil)
 	})
@@ -1142,9 +1168,9 @@ func TestNilPanicValue(t *testing.T) {
 		t.Error("Expected HasError to be true even for nil panic value")
 	}
 
-	// The error value will be a special runtime type, not nil itself
+	// The error value will be NilPanicError, not nil itself
 	if tb.GetError() == nil {
-		t.Error("Expected GetError to return non-nil (runtime.nilPanicError)")
+		t.Error("Expected GetError to return non-nil (NilPanicError)")
 	}
 }
 
@@ -1854,3 +1880,640 @@ func TestVersion_Exists(t *testing.T) {
 		t.Logf("Version is %s", Version)
 	}
 }
+
+// ============================================
+// BeforeThrow Tests
+// ============================================
+
+func TestBeforeThrow_Transform(t *testing.T) {
+	defer func() { BeforeThrow = nil }()
+	BeforeThrow = func(err interface{}) interface{} {
+		return fmt.Sprintf("sanitized: %v", err)
+	}
+
+	tb := Try(func() {
+		Throw("secret")
+	})
+
+	if tb.GetError() != "sanitized: secret" {
+		t.Errorf("Expected transformed value, got %v", tb.GetError())
+	}
+}
+
+func TestBeforeThrow_Veto(t *testing.T) {
+	defer func() { BeforeThrow = nil }()
+	var seen interface{}
+	BeforeThrow = func(err interface{}) interface{} {
+		seen = err
+		return nil
+	}
+
+	var reachedEnd bool
+	tb := Try(func() {
+		Throw("ignored")
+		reachedEnd = true
+	})
+
+	if tb.HasError() {
+		t.Errorf("Expected vetoed throw not to panic, got %v", tb.GetError())
+	}
+	if !reachedEnd {
+		t.Error("Expected execution to continue after vetoed Throw")
+	}
+	if seen != "ignored" {
+		t.Errorf("Expected BeforeThrow to receive 'ignored', got %v", seen)
+	}
+}
+
+func TestBeforeThrow_AppliesToAssert(t *testing.T) {
+	defer func() { BeforeThrow = nil }()
+	BeforeThrow = func(err interface{}) interface{} {
+		return errors.New("wrapped")
+	}
+
+	tb := Try(func() {
+		Assert(false, "assert failed")
+	})
+
+	if err, ok := tb.GetError().(error); !ok || err.Error() != "wrapped" {
+		t.Errorf("Expected Assert to go through BeforeThrow, got %v", tb.GetError())
+	}
+}
+
+// ============================================
+// Set/Get Tests
+// ============================================
+
+func TestSetGet_CatchToFinally(t *testing.T) {
+	var fallback interface{}
+
+	tb := Try(func() {
+		panic("lookup failed")
+	})
+	tb = Catch[string](tb, func(err string) {
+		tb.Set("fallback", "cached-value")
+	})
+	tb.Finally(func() {
+		fallback, _ = tb.Get("fallback")
+	})
+
+	if fallback != "cached-value" {
+		t.Errorf("Expected 'cached-value' in Finally, got %v", fallback)
+	}
+}
+
+func TestSetGet_Missing(t *testing.T) {
+	tb := Try(func() {})
+
+	if val, ok := tb.Get("missing"); ok || val != nil {
+		t.Errorf("Expected (nil, false), got (%v, %v)", val, ok)
+	}
+
+	tb.Set("a", 1).Set("b", nil)
+	if val, ok := tb.Get("a"); !ok || val != 1 {
+		t.Errorf("Expected (1, true), got (%v, %v)", val, ok)
+	}
+	if val, ok := tb.Get("b"); !ok || val != nil {
+		t.Errorf("Expected stored nil to be present, got (%v, %v)", val, ok)
+	}
+}
+
+func TestSetGet_NilTryBlock(t *testing.T) {
+	var tb *TryBlock
+	if tb.Set("k", "v") != nil {
+		t.Error("Expected Set on nil TryBlock to return nil")
+	}
+	if _, ok := tb.Get("k"); ok {
+		t.Error("Expected Get on nil TryBlock to report missing")
+	}
+}
+
+// ============================================
+// UnhandledPolicy Tests
+// ============================================
+
+func TestUnhandledPolicy_RecordsInsteadOfPanicking(t *testing.T) {
+	defer func() { UnhandledPolicy = nil }()
+	var recorded []interface{}
+	UnhandledPolicy = func(err interface{}) {
+		recorded = append(recorded, err)
+	}
+
+	var finallyCalled bool
+	outer := Try(func() {
+		Try(func() {
+			panic("unhandled")
+		}).Finally(func() {
+			finallyCalled = true
+		})
+	})
+
+	if outer.HasError() {
+		t.Errorf("Expected no panic to escape, got %v", outer.GetError())
+	}
+	if !finallyCalled {
+		t.Error("Expected Finally function to run")
+	}
+	if len(recorded) != 1 || recorded[0] != "unhandled" {
+		t.Errorf("Expected policy to record 'unhandled', got %v", recorded)
+	}
+}
+
+func TestUnhandledPolicy_TryWithResult(t *testing.T) {
+	defer func() { UnhandledPolicy = nil }()
+	var recorded interface{}
+	UnhandledPolicy = func(err interface{}) { recorded = err }
+
+	result := TryWithResult(func() int {
+		panic(7)
+	}).Finally(func() {})
+
+	if recorded != 7 {
+		t.Errorf("Expected policy to receive 7, got %v", recorded)
+	}
+	if result != 0 {
+		t.Errorf("Expected zero result, got %v", result)
+	}
+}
+
+func TestUnhandledPolicy_NotCalledWhenHandled(t *testing.T) {
+	defer func() { UnhandledPolicy = nil }()
+	var called bool
+	UnhandledPolicy = func(err interface{}) { called = true }
+
+	Try(func() { panic("x") }).CatchAny(func(interface{}) {}).Finally(func() {})
+	Try(func() {}).Finally(func() {})
+
+	if called {
+		t.Error("Expected policy not to be called for handled or clean blocks")
+	}
+}
+
+// ============================================
+// Assertf Tests
+// ============================================
+
+func TestAssertf_Failure(t *testing.T) {
+	var caught *AssertionError
+	var line int
+
+	tb := Try(func() {
+		_, _, line, _ = runtime.Caller(0)
+		Assertf(false, "expected %d items, got %d", 3, 2)
+	})
+	Catch[*AssertionError](tb, func(err *AssertionError) { caught = err })
+
+	if caught == nil {
+		t.Fatalf("Expected *AssertionError, got %T", tb.GetError())
+	}
+	if caught.Message != "expected 3 items, got 2" {
+		t.Errorf("Expected formatted message, got %q", caught.Message)
+	}
+	want := fmt.Sprintf("gotrycatch_test.go:%d", line+1)
+	if caught.Location != want {
+		t.Errorf("Expected location %s, got %s", want, caught.Location)
+	}
+	if caught.Error() != "assertion failed: expected 3 items, got 2 (at "+want+")" {
+		t.Errorf("Unexpected error message: %s", caught.Error())
+	}
+}
+
+func TestAssertf_Success(t *testing.T) {
+	tb := Try(func() {
+		Assertf(true, "never %s", "thrown")
+	})
+
+	if tb.HasError() {
+		t.Errorf("Expected no error, got %v", tb.GetError())
+	}
+}
+
+// ============================================
+// NilPanicError Tests
+// ============================================
+
+func TestNilPanicError_Catch(t *testing.T) {
+	var caught bool
+	tb := Try(func() {
+		panic(nil)
+	})
+	Catch[NilPanicError](tb, func(NilPanicError) { caught = true })
+
+	if !caught {
+		t.Errorf("Expected Catch[NilPanicError] to fire, got %T", tb.GetError())
+	}
+}
+
+func TestNilPanicError_CleanBlock(t *testing.T) {
+	var caught bool
+	tb := Try(func() {})
+	Catch[NilPanicError](tb, func(NilPanicError) { caught = true })
+
+	if caught || tb.HasError() {
+		t.Error("Expected clean block not to produce NilPanicError")
+	}
+}
+
+func TestNilPanicError_TryWithResult(t *testing.T) {
+	tb := TryWithResult(func() int {
+		panic(nil)
+	})
+
+	if _, ok := tb.GetError().(NilPanicError); !ok {
+		t.Errorf("Expected NilPanicError, got %T", tb.GetError())
+	}
+}
+
+func TestNormalizePanic(t *testing.T) {
+	if normalizePanic(nil, true) != nil {
+		t.Error("Expected nil for a completed function")
+	}
+	if _, ok := normalizePanic(nil, false).(NilPanicError); !ok {
+		t.Error("Expected NilPanicError for a nil value from an incomplete function")
+	}
+	if normalizePanic("boom", false) != "boom" {
+		t.Error("Expected other values to pass through")
+	}
+}
+
+// ============================================
+// Error/ErrOrNil Tests
+// ============================================
+
+func TestErrOrNil_Clean(t *testing.T) {
+	if err := Try(func() {}).ErrOrNil(); err != nil {
+		t.Errorf("Expected nil for clean block, got %v", err)
+	}
+
+	handled := Catch[string](Try(func() { panic("x") }), func(string) {})
+	if err := handled.ErrOrNil(); err != nil {
+		t.Errorf("Expected nil for handled block, got %v", err)
+	}
+
+	var nilBlock *TryBlock
+	if err := nilBlock.ErrOrNil(); err != nil {
+		t.Errorf("Expected nil for nil block, got %v", err)
+	}
+}
+
+func TestErrOrNil_Unhandled(t *testing.T) {
+	dbErr := trycatcherrors.NewDatabaseError("SELECT", "users", nil)
+
+	err := Try(func() { panic(dbErr) }).ErrOrNil()
+	if err == nil {
+		t.Fatal("Expected an error for unhandled block")
+	}
+	if err.Error() != dbErr.Error() {
+		t.Errorf("Expected message %q, got %q", dbErr.Error(), err.Error())
+	}
+
+	var target trycatcherrors.DatabaseError
+	if !errors.As(err, &target) || target.Table != "users" {
+		t.Error("Expected errors.As to reach the captured DatabaseError")
+	}
+}
+
+func TestErrOrNil_Formatting(t *testing.T) {
+	boom := errors.New("boom")
+	err := Try(func() { panic(boom) }).ErrOrNil()
+
+	if got := fmt.Sprintf("%v", err); got != "boom" {
+		t.Errorf("Expected %%v to print the message, got %q", got)
+	}
+	wrapped := fmt.Errorf("wrap: %w", err)
+	if wrapped.Error() != "wrap: boom" {
+		t.Errorf("Expected wrapped message 'wrap: boom', got %q", wrapped.Error())
+	}
+	if !errors.Is(wrapped, boom) {
+		t.Error("Expected errors.Is to reach the captured error through %w")
+	}
+
+	if got := fmt.Sprint(Try(func() { panic("plain") }).ErrOrNil()); got != "plain" {
+		t.Errorf("Expected non-error value to print as its message, got %q", got)
+	}
+}
+
+func TestTryBlockUnwrap_Handled(t *testing.T) {
+	boom := errors.New("boom")
+	tb := Try(func() { panic(boom) })
+
+	if !errors.Is(tb, boom) {
+		t.Error("Expected errors.Is to reach the error of an unhandled block")
+	}
+
+	tb = Catch[error](tb, func(error) {})
+	if tb.Unwrap() != nil || errors.Is(tb, boom) {
+		t.Error("Expected a handled block not to unwrap to its error")
+	}
+	if tb.Error() != "" || tb.ErrOrNil() != nil {
+		t.Error("Expected a handled block to read as no error")
+	}
+}
+
+func TestTryBlockError_Messages(t *testing.T) {
+	if got := Try(func() { panic("boom") }).Error(); got != "boom" {
+		t.Errorf("Expected 'boom', got %q", got)
+	}
+	if got := Try(func() {}).Error(); got != "" {
+		t.Errorf("Expected empty message for clean block, got %q", got)
+	}
+}
+
+func TestTryBlockFormat_UsesString(t *testing.T) {
+	tb := Try(func() { panic("boom") })
+
+	if got := fmt.Sprintf("%s", tb); got != tb.String() {
+		t.Errorf("Expected %%s to use String, got %q", got)
+	}
+	if got := fmt.Sprintf("%q", tb); got != fmt.Sprintf("%q", tb.String()) {
+		t.Errorf("Expected %%q to quote String, got %s", got)
+	}
+}
+
+// ============================================
+// SafeFinally Tests
+// ============================================
+
+func TestSafeFinally_BothPanic(t *testing.T) {
+	outer := Try(func() {
+		Try(func() { panic("block") }).SafeFinally(func() { panic("finally") })
+	})
+
+	multi, ok := outer.GetError().(MultiError)
+	if !ok {
+		t.Fatalf("Expected MultiError, got %T: %v", outer.GetError(), outer.GetError())
+	}
+	if len(multi.Errors) != 2 || multi.Errors[0] != "block" || multi.Errors[1] != "finally" {
+		t.Errorf("Expected [block finally], got %v", multi.Errors)
+	}
+}
+
+func TestSafeFinally_OnlyFinallyPanics(t *testing.T) {
+	outer := Try(func() {
+		Try(func() {}).SafeFinally(func() { panic("finally") })
+	})
+
+	if outer.GetError() != "finally" {
+		t.Errorf("Expected finally panic to propagate unchanged, got %v", outer.GetError())
+	}
+}
+
+func TestSafeFinally_LikeFinally(t *testing.T) {
+	var cleaned bool
+	outer := Try(func() {
+		Try(func() { panic(42) }).SafeFinally(func() { cleaned = true })
+	})
+
+	if !cleaned || outer.GetError() != 42 {
+		t.Errorf("Expected cleanup and re-throw of 42, got cleaned=%v err=%v", cleaned, outer.GetError())
+	}
+
+	handled := Try(func() {
+		Catch[string](Try(func() { panic("x") }), func(string) {}).SafeFinally(nil)
+	})
+	if handled.HasError() {
+		t.Errorf("Expected no re-throw for handled block, got %v", handled.GetError())
+	}
+}
+
+func TestFinally_FinallyPanicMasksError(t *testing.T) {
+	// Documents the behavior SafeFinally exists to avoid.
+	outer := Try(func() {
+		Try(func() { panic("block") }).Finally(func() { panic("finally") })
+	})
+
+	if outer.GetError() != "finally" {
+		t.Errorf("Expected finally panic to replace the block error, got %v", outer.GetError())
+	}
+}
+
+// ============================================
+// FlattenRethrows Tests
+// ============================================
+
+func TestFlattenRethrows_NestedFinallyLayers(t *testing.T) {
+	WrapRethrows = true
+	defer func() { WrapRethrows = false }()
+
+	dbErr := trycatcherrors.NewDatabaseError("SELECT", "users", nil)
+	nested := func() *RethrowError {
+		return Try(func() {
+			Try(func() {
+				Try(func() { panic(dbErr) }).Finally(func() {})
+			}).Finally(func() {})
+		}).GetError().(*RethrowError)
+	}
+
+	if _, isNested := nested().Value.(*RethrowError); !isNested {
+		t.Fatal("Expected nested wrappers without FlattenRethrows")
+	}
+
+	FlattenRethrows = true
+	defer func() { FlattenRethrows = false }()
+
+	if _, ok := nested().Value.(trycatcherrors.DatabaseError); !ok {
+		t.Error("Expected a single wrapper around the original DatabaseError after two layers")
+	}
+}
+
+func TestFlattenRethrows_SafeFinallyLayers(t *testing.T) {
+	nested := func() interface{} {
+		return Try(func() {
+			Try(func() {
+				Try(func() { panic("block") }).SafeFinally(func() { panic("inner cleanup") })
+			}).SafeFinally(func() { panic("outer cleanup") })
+		}).GetError()
+	}
+
+	multi := nested().(MultiError)
+	if _, isNested := multi.Errors[0].(MultiError); !isNested {
+		t.Fatalf("Expected nesting without FlattenRethrows, got %v", multi.Errors)
+	}
+
+	FlattenRethrows = true
+	defer func() { FlattenRethrows = false }()
+
+	multi = nested().(MultiError)
+	if len(multi.Errors) != 3 {
+		t.Fatalf("Expected 3 flat errors, got %v", multi.Errors)
+	}
+	for i, want := range []string{"block", "inner cleanup", "outer cleanup"} {
+		if multi.Errors[i] != want {
+			t.Errorf("Expected %q at %d, got %v", want, i, multi.Errors[i])
+		}
+	}
+}
+
+// ============================================
+// CatchAnyWithReturn Tests
+// ============================================
+
+func TestCatchAnyWithReturn_AnyType(t *testing.T) {
+	for _, value := range []interface{}{"boom", 42, errors.New("err"), trycatcherrors.NewValidationError("f", "m", 1)} {
+		result, tb := CatchAnyWithReturn(Try(func() { panic(value) }), func(err interface{}) interface{} {
+			return fmt.Sprintf("fallback for %T", err)
+		})
+
+		if result != fmt.Sprintf("fallback for %T", value) {
+			t.Errorf("Expected fallback for %T, got %v", value, result)
+		}
+		if !tb.IsHandled() {
+			t.Errorf("Expected handled for %T", value)
+		}
+	}
+}
+
+func TestCatchAnyWithReturn_NotFired(t *testing.T) {
+	handler := func(interface{}) interface{} { return "fallback" }
+
+	if result, _ := CatchAnyWithReturn(Try(func() {}), handler); result != nil {
+		t.Errorf("Expected nil result for clean block, got %v", result)
+	}
+
+	handled := Catch[string](Try(func() { panic("x") }), func(string) {})
+	if result, _ := CatchAnyWithReturn(handled, handler); result != nil {
+		t.Errorf("Expected nil result for handled block, got %v", result)
+	}
+
+	if result, tb := CatchAnyWithReturn(nil, handler); result != nil || tb == nil {
+		t.Error("Expected nil result and non-nil TryBlock for nil input")
+	}
+}
+
+// ============================================
+// CatchReturnErr Tests
+// ============================================
+
+func TestCatchReturnErr_Value(t *testing.T) {
+	tb := Try(func() { panic(trycatcherrors.NewValidationError("age", "negative", 1002)) })
+
+	result, err, tb := CatchReturnErr(tb, func(e trycatcherrors.ValidationError) (int, error) {
+		return 0, nil
+	})
+
+	if result != 0 || err != nil {
+		t.Errorf("Expected (0, nil), got (%v, %v)", result, err)
+	}
+	if !tb.IsHandled() {
+		t.Error("Expected block to be handled")
+	}
+}
+
+func TestCatchReturnErr_Error(t *testing.T) {
+	tb := Try(func() { panic("lookup failed") })
+	wrapped := errors.New("user service unavailable")
+
+	result, err, tb := CatchReturnErr(tb, func(msg string) (string, error) {
+		return "partial", wrapped
+	})
+
+	if result != "partial" || err != wrapped {
+		t.Errorf("Expected (partial, %v), got (%v, %v)", wrapped, result, err)
+	}
+	if tb.IsHandled() || tb.GetError() != wrapped {
+		t.Errorf("Expected returned error stored unhandled, got %v", tb)
+	}
+
+	var caught error
+	Catch[error](tb, func(e error) { caught = e })
+	if caught != wrapped {
+		t.Errorf("Expected later catch to see the returned error, got %v", caught)
+	}
+}
+
+func TestCatchReturnErr_NotFired(t *testing.T) {
+	handler := func(int) (string, error) { return "value", nil }
+
+	result, err, tb := CatchReturnErr(Try(func() { panic("not an int") }), handler)
+	if result != "" || err != nil || tb.IsHandled() {
+		t.Errorf("Expected zero results and unhandled block, got (%q, %v, %v)", result, err, tb)
+	}
+
+	if result, err, tb := CatchReturnErr(nil, handler); result != "" || err != nil || tb == nil {
+		t.Error("Expected zero results and non-nil TryBlock for nil input")
+	}
+}
+
+// ============================================
+// AnnotateStringThrows Tests
+// ============================================
+
+func TestAnnotateStringThrows_Enabled(t *testing.T) {
+	AnnotateStringThrows = true
+	defer func() { AnnotateStringThrows = false }()
+
+	_, _, line, _ := runtime.Caller(0)
+	tb := Try(func() { Throw("not found") })
+
+	want := fmt.Sprintf("not found (at gotrycatch_test.go:%d)", line+1)
+	if tb.GetError() != want {
+		t.Errorf("Expected %q, got %v", want, tb.GetError())
+	}
+
+	var caught bool
+	Catch[string](tb, func(string) { caught = true })
+	if !caught {
+		t.Error("Expected annotated value to stay catchable as a string")
+	}
+}
+
+func TestAnnotateStringThrows_SkipsHelpers(t *testing.T) {
+	AnnotateStringThrows = true
+	defer func() { AnnotateStringThrows = false }()
+
+	_, _, line, _ := runtime.Caller(0)
+	tb := Try(func() { Assert(false, "invariant broken") })
+
+	want := fmt.Sprintf("invariant broken (at gotrycatch_test.go:%d)", line+1)
+	if tb.GetError() != want {
+		t.Errorf("Expected location of the Assert caller %q, got %v", want, tb.GetError())
+	}
+
+	if tb := Try(func() { Throw(42) }); tb.GetError() != 42 {
+		t.Errorf("Expected non-string values unchanged, got %v", tb.GetError())
+	}
+}
+
+func TestAnnotateStringThrows_Disabled(t *testing.T) {
+	if tb := Try(func() { Throw("raw") }); tb.GetError() != "raw" {
+		t.Errorf("Expected raw message when disabled, got %v", tb.GetError())
+	}
+}
+
+// ============================================
+// Take Tests
+// ============================================
+
+func TestTake_PreventsRethrowInFinally(t *testing.T) {
+	tb := Try(func() { panic("custom handling") })
+
+	if got := tb.Take(); got != "custom handling" {
+		t.Errorf("Expected taken value, got %v", got)
+	}
+	if tb.HasError() || !tb.IsHandled() {
+		t.Errorf("Expected a cleared, handled block, got %v", tb)
+	}
+
+	finallyRan := false
+	if SuppressPanics(func() { tb.Finally(func() { finallyRan = true }) }) {
+		t.Error("Expected Finally not to re-panic after Take")
+	}
+	if !finallyRan {
+		t.Error("Expected Finally callback to run")
+	}
+}
+
+func TestTake_NoError(t *testing.T) {
+	clean := Try(func() {})
+	if got := clean.Take(); got != nil {
+		t.Errorf("Expected nil from clean block, got %v", got)
+	}
+	if clean.IsHandled() {
+		t.Error("Expected Take on a clean block not to mark it handled")
+	}
+
+	var nilTb *TryBlock
+	if nilTb.Take() != nil {
+		t.Error("Expected nil from nil TryBlock")
+	}
+}
@@ -0,0 +1,85 @@
+package gotrycatch
+
+import "sync"
+
+// ============================================
+// Group - Concurrent tasks with collected panics
+// ============================================
+
+// Group runs functions in goroutines and collects their panics instead of crashing
+// the process. The zero value is ready to use. A Group must not be copied after first use.
+//
+//	var g gotrycatch.Group
+//	g.Go(fetchUsers)
+//	g.Go(fetchOrders)
+//	g.Wait()
+//	gotrycatch.CatchEach[errors.NetworkError](&g, logNetworkError)
+type Group struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []interface{}
+}
+
+// Go runs fn in a new goroutine. A panic in fn is recovered and recorded on the group.
+func (g *Group) Go(fn func()) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if tb := Try(fn); tb.HasError() {
+			debugLog("Group: goroutine panicked with %T", tb.err)
+			g.mu.Lock()
+			g.errs = append(g.errs, tb.err)
+			g.mu.Unlock()
+		}
+	}()
+}
+
+// Wait blocks until all goroutines started with Go have returned.
+// The returned TryBlock is clean if nothing panicked; otherwise it holds a MultiError
+// of the remaining collected panics, in completion order.
+func (g *Group) Wait() *TryBlock {
+	g.wg.Wait()
+
+	errs := g.Errors()
+	if len(errs) == 0 {
+		return &TryBlock{}
+	}
+	return &TryBlock{err: MultiError{Errors: errs}}
+}
+
+// Errors returns a copy of the panics collected so far, in completion order.
+func (g *Group) Errors() []interface{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]interface{}(nil), g.errs...)
+}
+
+// CatchEach calls handler for every collected panic of type T and removes those
+// panics from the group, so later CatchEach calls and Wait only see the rest.
+// Call it after Wait to process all failures. A nil group or handler is a no-op.
+// Returns the same Group to allow chaining.
+func CatchEach[T any](g *Group, handler func(T)) *Group {
+	if g == nil || handler == nil {
+		debugLog("CatchEach: group or handler is nil, skipping")
+		return g
+	}
+
+	g.mu.Lock()
+	var matched []T
+	remaining := g.errs[:0:0]
+	for _, v := range g.errs {
+		if err, ok := v.(T); ok {
+			matched = append(matched, err)
+		} else {
+			remaining = append(remaining, v)
+		}
+	}
+	g.errs = remaining
+	g.mu.Unlock()
+
+	debugLog("CatchEach: %d collected panic(s) matched %T", len(matched), *new(T))
+	for _, err := range matched {
+		handler(err)
+	}
+	return g
+}
@@ -0,0 +1,105 @@
+package gotrycatch
+
+import (
+	"sort"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Group 测试
+// ============================================
+
+func TestGroup_CollectsPanics(t *testing.T) {
+	var g Group
+	g.Go(func() {})
+	g.Go(func() { panic("boom") })
+	g.Go(func() { panic(42) })
+
+	tb := g.Wait()
+
+	multi, ok := tb.GetError().(MultiError)
+	if !ok {
+		t.Fatalf("Expected MultiError, got %T", tb.GetError())
+	}
+	if len(multi.Errors) != 2 {
+		t.Errorf("Expected 2 collected panics, got %v", multi.Errors)
+	}
+}
+
+func TestGroup_Clean(t *testing.T) {
+	var g Group
+	g.Go(func() {})
+
+	if tb := g.Wait(); tb.HasError() {
+		t.Errorf("Expected clean block, got %v", tb.GetError())
+	}
+}
+
+// ============================================
+// CatchEach 测试
+// ============================================
+
+func TestCatchEach_MixedPanics(t *testing.T) {
+	var g Group
+	for _, table := range []string{"users", "orders", "items"} {
+		table := table
+		g.Go(func() { panic(trycatcherrors.NewDatabaseError("SELECT", table, nil)) })
+	}
+	g.Go(func() { panic(trycatcherrors.NewNetworkError("https://api.example.com", 503)) })
+	g.Go(func() { panic("plain") })
+	g.Wait()
+
+	var tables []string
+	var networkCalls int
+	CatchEach[trycatcherrors.DatabaseError](&g, func(err trycatcherrors.DatabaseError) {
+		tables = append(tables, err.Table)
+	})
+	CatchEach[trycatcherrors.NetworkError](&g, func(trycatcherrors.NetworkError) {
+		networkCalls++
+	})
+
+	sort.Strings(tables)
+	if len(tables) != 3 || tables[0] != "items" || tables[1] != "orders" || tables[2] != "users" {
+		t.Errorf("Expected handler for each DatabaseError, got %v", tables)
+	}
+	if networkCalls != 1 {
+		t.Errorf("Expected 1 NetworkError, got %d", networkCalls)
+	}
+
+	remaining := g.Errors()
+	if len(remaining) != 1 || remaining[0] != "plain" {
+		t.Errorf("Expected only the unmatched panic to remain, got %v", remaining)
+	}
+	if multi, ok := g.Wait().GetError().(MultiError); !ok || len(multi.Errors) != 1 {
+		t.Errorf("Expected Wait to report only the remaining panic, got %v", g.Wait().GetError())
+	}
+}
+
+func TestCatchEach_Drains(t *testing.T) {
+	var g Group
+	g.Go(func() { panic("a") })
+	g.Wait()
+
+	var calls int
+	CatchEach[string](&g, func(string) { calls++ })
+	CatchEach[string](&g, func(string) { calls++ })
+
+	if calls != 1 {
+		t.Errorf("Expected each panic to be handled once, got %d calls", calls)
+	}
+	if tb := g.Wait(); tb.HasError() {
+		t.Errorf("Expected group to be clean after draining, got %v", tb.GetError())
+	}
+}
+
+func TestCatchEach_NilCases(t *testing.T) {
+	if CatchEach[string](nil, func(string) {}) != nil {
+		t.Error("Expected nil group to be returned unchanged")
+	}
+	var g Group
+	if CatchEach[string](&g, nil) != &g {
+		t.Error("Expected same group for nil handler")
+	}
+}
@@ -0,0 +1,58 @@
+package gotrycatch
+
+import "strings"
+
+// ============================================
+// GRPCStatus - gRPC status codes for caught errors
+// ============================================
+
+// gRPC status codes, with the values of google.golang.org/grpc/codes, so the mapping
+// needs no gRPC dependency.
+const (
+	grpcOK                 = 0
+	grpcUnknown            = 2
+	grpcInvalidArgument    = 3
+	grpcResourceExhausted  = 8
+	grpcFailedPrecondition = 9
+	grpcInternal           = 13
+	grpcUnavailable        = 14
+	grpcUnauthenticated    = 16
+)
+
+// grpcCodes maps the TypeName of known error values to the code reported by
+// GRPCStatus. Pointer types resolve to the same code as their value types.
+var grpcCodes = map[string]int{
+	"errors.ValidationError":      grpcInvalidArgument,
+	"errors.ValidationErrors":     grpcInvalidArgument,
+	"errors.ValidationTree":       grpcInvalidArgument,
+	"errors.AuthError":            grpcUnauthenticated,
+	"errors.BusinessLogicError":   grpcFailedPrecondition,
+	"errors.RateLimitError":       grpcResourceExhausted,
+	"errors.NetworkError":         grpcUnavailable,
+	"errors.DatabaseError":        grpcInternal,
+	"errors.BatchDatabaseError":   grpcInternal,
+	"errors.ConfigError":          grpcInternal,
+	"gotrycatch.CircuitOpenError": grpcUnavailable,
+}
+
+// GRPCStatus returns the gRPC status code and message that best describe err, for a
+// server interceptor translating caught panics into status errors. The code has the
+// numeric value of google.golang.org/grpc/codes, so an interceptor converts it with
+//
+//	code, msg := gotrycatch.GRPCStatus(err)
+//	return status.Error(codes.Code(code), msg)
+//
+// ValidationError maps to InvalidArgument, DatabaseError to Internal, NetworkError to
+// Unavailable, BusinessLogicError to FailedPrecondition, AuthError to Unauthenticated
+// and RateLimitError to ResourceExhausted. A nil error maps to OK with an empty message
+// and any other value maps to Unknown.
+func GRPCStatus(err interface{}) (code int, message string) {
+	if err == nil {
+		return grpcOK, ""
+	}
+	code, ok := grpcCodes[strings.TrimPrefix(TypeName(err), "*")]
+	if !ok {
+		code = grpcUnknown
+	}
+	return code, errorMessage(err)
+}
@@ -0,0 +1,46 @@
+package gotrycatch
+
+import (
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// GRPCStatus 测试
+// ============================================
+
+func TestGRPCStatus(t *testing.T) {
+	validation := trycatcherrors.NewValidationError("email", "invalid", 1001)
+	tests := []struct {
+		name string
+		err  interface{}
+		want int
+	}{
+		{"validation", validation, grpcInvalidArgument},
+		{"validation pointer", &validation, grpcInvalidArgument},
+		{"validation tree", trycatcherrors.NewValidationTree(), grpcInvalidArgument},
+		{"database", trycatcherrors.NewDatabaseError("INSERT", "users", nil), grpcInternal},
+		{"network", trycatcherrors.NewNetworkError("https://api.example.com", 503), grpcUnavailable},
+		{"business", trycatcherrors.NewBusinessLogicError("credit", "limit exceeded"), grpcFailedPrecondition},
+		{"auth", trycatcherrors.NewAuthError("login", "bob", "bad password"), grpcUnauthenticated},
+		{"rate limit", trycatcherrors.NewRateLimitError("api", 10, 11, 1), grpcResourceExhausted},
+		{"circuit open", CircuitOpenError{}, grpcUnavailable},
+		{"unknown", "boom", grpcUnknown},
+	}
+	for _, tt := range tests {
+		code, msg := GRPCStatus(tt.err)
+		if code != tt.want {
+			t.Errorf("%s: expected code %d, got %d", tt.name, tt.want, code)
+		}
+		if msg != errorMessage(tt.err) {
+			t.Errorf("%s: expected message %q, got %q", tt.name, errorMessage(tt.err), msg)
+		}
+	}
+}
+
+func TestGRPCStatus_Nil(t *testing.T) {
+	if code, msg := GRPCStatus(nil); code != grpcOK || msg != "" {
+		t.Errorf("Expected OK with empty message, got %d %q", code, msg)
+	}
+}
@@ -0,0 +1,67 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ============================================
+// Main - Top-level safety net for main()
+// ============================================
+
+// exitFunc and mainOutput are variables so tests can intercept process exit and output.
+var (
+	exitFunc             = os.Exit
+	mainOutput io.Writer = os.Stderr
+)
+
+// Main runs fn as the body of a program's main function.
+// If fn panics, a formatted report with the error type, message and stack (the captured
+// panic stack when CaptureStack is on, else the error's own) is written to standard
+// error and the process exits with status 1.
+// Otherwise Main returns normally.
+//
+//	func main() {
+//		gotrycatch.Main(func() {
+//			run()
+//		})
+//	}
+func Main(fn func()) {
+	tb := Try(fn)
+	if !tb.HasError() {
+		return
+	}
+
+	debugLog("Main: unhandled panic of type %T, exiting", tb.err)
+	writePanicReport(mainOutput, tb)
+	exitFunc(1)
+}
+
+// writePanicReport writes a human-readable report of the unhandled panic in tb to w.
+func writePanicReport(w io.Writer, tb *TryBlock) {
+	var b strings.Builder
+	b.WriteString("[gotrycatch] fatal: unhandled panic\n")
+	fmt.Fprintf(&b, "  type:  %T\n", tb.err)
+	fmt.Fprintf(&b, "  error: %s\n", Describe(tb.err))
+
+	if stack := panicStack(tb); len(stack) > 0 {
+		b.WriteString("  stack:\n")
+		for _, frame := range stack {
+			fmt.Fprintf(&b, "    %s\n", frame)
+		}
+	}
+	io.WriteString(w, b.String())
+}
+
+// stackOf returns the stack trace carried by an error value, if any.
+// Built-in error types expose it through ToMap()["stack"].
+func stackOf(err interface{}) []string {
+	if m, ok := err.(interface{ ToMap() map[string]interface{} }); ok {
+		if stack, ok := m.ToMap()["stack"].([]string); ok {
+			return stack
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package gotrycatch
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Main 测试
+// ============================================
+
+// interceptMain replaces the exit function and output for the duration of a test.
+func interceptMain(t *testing.T) (*bytes.Buffer, *int) {
+	t.Helper()
+	var buf bytes.Buffer
+	code := -1
+
+	oldExit, oldOutput := exitFunc, mainOutput
+	exitFunc = func(c int) { code = c }
+	mainOutput = &buf
+	t.Cleanup(func() { exitFunc, mainOutput = oldExit, oldOutput })
+
+	return &buf, &code
+}
+
+func TestMain_NoPanic(t *testing.T) {
+	buf, code := interceptMain(t)
+
+	var ran bool
+	Main(func() { ran = true })
+
+	if !ran {
+		t.Error("Expected fn to run")
+	}
+	if *code != -1 {
+		t.Errorf("Expected no exit, got exit code %d", *code)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output, got %q", buf.String())
+	}
+}
+
+func TestMain_PanicWithStack(t *testing.T) {
+	buf, code := interceptMain(t)
+
+	Main(func() {
+		panic(trycatcherrors.NewValidationError("email", "invalid format", 1001))
+	})
+
+	if *code != 1 {
+		t.Errorf("Expected exit code 1, got %d", *code)
+	}
+	report := buf.String()
+	for _, want := range []string{
+		"fatal: unhandled panic",
+		"type:  errors.ValidationError",
+		"error: validation error [1001] on field 'email'",
+		"stack:",
+		"guard_test.go",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+}
+
+func TestMain_PanicWithoutStack(t *testing.T) {
+	buf, code := interceptMain(t)
+
+	Main(func() { panic("plain failure") })
+
+	if *code != 1 {
+		t.Errorf("Expected exit code 1, got %d", *code)
+	}
+	report := buf.String()
+	if !strings.Contains(report, "type:  string") || !strings.Contains(report, "error: plain failure") {
+		t.Errorf("Unexpected report:\n%s", report)
+	}
+	if strings.Contains(report, "stack:") {
+		t.Errorf("Expected no stack section for a string panic, got:\n%s", report)
+	}
+}
+
+func TestMain_CapturedStackForPlainPanic(t *testing.T) {
+	buf, _ := interceptMain(t)
+	CaptureStack = true
+	defer func() { CaptureStack = false }()
+
+	Main(panicFromHelper)
+
+	report := buf.String()
+	if !strings.Contains(report, "stack:") || !strings.Contains(report, "gotrycatch.panicFromHelper") {
+		t.Errorf("Expected captured panic stack in report, got:\n%s", report)
+	}
+}
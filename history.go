@@ -0,0 +1,70 @@
+package gotrycatch
+
+import (
+	"log/slog"
+	"reflect"
+)
+
+// ============================================
+// RecordHistory - Attempted catches on unhandled errors
+// ============================================
+
+// RecordHistory makes typed catches remember which types they tried against a block's
+// error while it was unhandled, for diagnosing why nothing matched. The attempts are
+// available via Attempts and logged by LogUnhandled. It is off by default.
+var RecordHistory = false
+
+// noteCatch is called on entry of every typed catch for T: it checks the chain's
+// ordering and records the attempt when RecordHistory is on.
+func noteCatch[T any](tb *TryBlock, caller string) {
+	if DebugOrdering || RecordHistory {
+		noteCatchTarget(tb, caller, reflect.TypeFor[T]().String())
+	}
+}
+
+// noteCatchTarget is noteCatch for catches whose target is not a type parameter, such
+// as CatchReflect or CatchCodeRange; target describes what the catch looks for.
+func noteCatchTarget(tb *TryBlock, caller, target string) {
+	if DebugOrdering && tb.handledByAny {
+		warnOrdering(caller, target, tb.err)
+	}
+	if RecordHistory {
+		recordAttempt(tb, caller, target)
+	}
+}
+
+// recordAttempt appends caller[target] to the block's history if its error is unhandled.
+func recordAttempt(tb *TryBlock, caller, target string) {
+	if tb.err != nil && !tb.handled {
+		tb.attempts = append(tb.attempts, caller+"["+target+"]")
+	}
+}
+
+// Attempts returns the typed catches tried against the block's error while it was
+// unhandled, in order, such as "Catch[errors.ValidationError]".
+// Returns nil unless RecordHistory was enabled when the catches ran.
+func (tb *TryBlock) Attempts() []string {
+	if tb == nil {
+		return nil
+	}
+	return append([]string(nil), tb.attempts...)
+}
+
+// LogUnhandled logs the block's error at error level if it is still unhandled, with its
+// type, message and the catches attempted (see RecordHistory), so a single record shows
+// why nothing matched. A nil logger uses slog.Default(). Clean and handled blocks are
+// not logged.
+func LogUnhandled(tb *TryBlock, logger *slog.Logger) {
+	if tb == nil || tb.err == nil || tb.handled {
+		return
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	logger.Error("gotrycatch: unhandled panic",
+		slog.String("type", TypeName(tb.err)),
+		slog.String("error", Describe(tb.err)),
+		slog.Any("attempted", tb.Attempts()),
+	)
+}
@@ -0,0 +1,121 @@
+package gotrycatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// RecordHistory / LogUnhandled 测试
+// ============================================
+
+func enableHistory(t *testing.T) {
+	t.Helper()
+	old := RecordHistory
+	RecordHistory = true
+	t.Cleanup(func() { RecordHistory = old })
+}
+
+func TestLogUnhandled_ListsAttemptedCatches(t *testing.T) {
+	enableHistory(t)
+
+	tb := Try(func() { panic("unexpected") })
+	tb = Catch[trycatcherrors.ValidationError](tb, func(trycatcherrors.ValidationError) {})
+	tb = CatchChain[trycatcherrors.NetworkError](tb, func(trycatcherrors.NetworkError) {})
+	tb = CatchReflect(tb, reflect.TypeFor[int](), func(interface{}) {})
+
+	want := []string{"Catch[errors.ValidationError]", "CatchChain[errors.NetworkError]", "CatchReflect[int]"}
+	if got := tb.Attempts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected attempts %v, got %v", want, got)
+	}
+
+	var buf bytes.Buffer
+	LogUnhandled(tb, slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	var record struct {
+		Msg       string   `json:"msg"`
+		Type      string   `json:"type"`
+		Error     string   `json:"error"`
+		Attempted []string `json:"attempted"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected one JSON record, got %q: %v", buf.String(), err)
+	}
+	if record.Type != "string" || record.Error != "unexpected" || !reflect.DeepEqual(record.Attempted, want) {
+		t.Errorf("Unexpected log record %+v", record)
+	}
+}
+
+func TestAttempts_UntypedCatches(t *testing.T) {
+	enableHistory(t)
+
+	tb := Try(func() { panic("unexpected") })
+	tb = CatchMap[int](tb, func(int) interface{} { return nil })
+	tb = CatchObserve[error](tb, func(error) {})
+	tb = CatchStringMatch(tb, "timeout", func(string) {})
+	tb = CatchCodeRange(tb, 400, 499, func(interface{}) {})
+	tb = CatchRetryable(tb, func(interface{}) {})
+	tb = CatchField(tb, "address.*", func(trycatcherrors.ValidationError) {})
+	tb = tb.Dispatch(NewHandlerTable().Add(On(func(float64) {})))
+
+	want := []string{
+		"CatchMap[int]", "CatchObserve[error]", `CatchStringMatch["timeout"]`, "CatchCodeRange[400-499]",
+		"CatchRetryable[gotrycatch.Retryable]", "CatchField[address.*]", "Dispatch[float64]",
+	}
+	if got := tb.Attempts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected attempts %v, got %v", want, got)
+	}
+}
+
+func TestAttempts_CatchWithReturn(t *testing.T) {
+	enableHistory(t)
+
+	_, tb := CatchWithReturn[int](Try(func() { panic("unexpected") }), func(int) interface{} { return nil })
+
+	if got := tb.Attempts(); !reflect.DeepEqual(got, []string{"CatchWithReturn[int]"}) {
+		t.Errorf("Expected CatchWithReturn attempt, got %v", got)
+	}
+}
+
+func TestAttempts_CatchReturnErr(t *testing.T) {
+	enableHistory(t)
+
+	_, _, tb := CatchReturnErr[error](Try(func() { panic("unexpected") }), func(error) (int, error) { return 0, nil })
+
+	if got := tb.Attempts(); !reflect.DeepEqual(got, []string{"CatchReturnErr[error]"}) {
+		t.Errorf("Expected CatchReturnErr attempt, got %v", got)
+	}
+}
+
+func TestLogUnhandled_SkipsHandled(t *testing.T) {
+	enableHistory(t)
+
+	tb := Try(func() { panic("handled") })
+	tb = Catch[int](tb, func(int) {})
+	tb = Catch[string](tb, func(string) {})
+	tb = Catch[error](tb, func(error) {})
+
+	if got := tb.Attempts(); !reflect.DeepEqual(got, []string{"Catch[int]", "Catch[string]"}) {
+		t.Errorf("Expected attempts only while unhandled, got %v", got)
+	}
+
+	var buf bytes.Buffer
+	LogUnhandled(tb, slog.New(slog.NewJSONHandler(&buf, nil)))
+	LogUnhandled(Try(func() {}), slog.New(slog.NewJSONHandler(&buf, nil)))
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing logged, got %q", buf.String())
+	}
+}
+
+func TestAttempts_Disabled(t *testing.T) {
+	tb := Catch[int](Try(func() { panic("x") }), func(int) {})
+
+	if tb.Attempts() != nil {
+		t.Errorf("Expected no attempts without RecordHistory, got %v", tb.Attempts())
+	}
+}
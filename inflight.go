@@ -0,0 +1,93 @@
+package gotrycatch
+
+import (
+	"bytes"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ============================================
+// In-flight tracking - Debugging hangs and deadlocks
+// ============================================
+
+// TrackInFlight enables recording of every running Try / TryWithResult call.
+// It is off by default because it adds a caller lookup and a map update to each Try.
+var TrackInFlight = false
+
+// InFlightInfo describes a protected block that is currently running.
+type InFlightInfo struct {
+	ID          uint64    // Unique id of this Try invocation
+	GoroutineID uint64    // Goroutine running the block
+	File        string    // File of the Try call site
+	Line        int       // Line of the Try call site
+	Function    string    // Function containing the Try call site
+	Started     time.Time // When the block started running
+}
+
+var (
+	inFlightMu  sync.Mutex
+	inFlight    = make(map[uint64]InFlightInfo)
+	inFlightSeq uint64
+)
+
+// InFlight returns a snapshot of the currently running protected blocks, oldest first.
+// It returns an empty slice when TrackInFlight is disabled.
+func InFlight() []InFlightInfo {
+	inFlightMu.Lock()
+	infos := make([]InFlightInfo, 0, len(inFlight))
+	for _, info := range inFlight {
+		infos = append(infos, info)
+	}
+	inFlightMu.Unlock()
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// trackStart registers the caller (skip frames above trackStart's caller) as in flight
+// and returns a function that removes the registration.
+func trackStart(skip int) func() {
+	info := InFlightInfo{
+		ID:          atomic.AddUint64(&inFlightSeq, 1),
+		GoroutineID: goroutineID(),
+		Started:     time.Now(),
+	}
+	if pc, file, line, ok := runtime.Caller(skip + 1); ok {
+		info.File = file
+		info.Line = line
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			info.Function = fn.Name()
+		}
+	}
+
+	inFlightMu.Lock()
+	inFlight[info.ID] = info
+	inFlightMu.Unlock()
+	debugLog("InFlight: started #%d on goroutine %d at %s:%d", info.ID, info.GoroutineID, info.File, info.Line)
+
+	return func() {
+		inFlightMu.Lock()
+		delete(inFlight, info.ID)
+		inFlightMu.Unlock()
+	}
+}
+
+// goroutineID returns the id of the current goroutine, parsed from the
+// "goroutine N [status]:" header of runtime.Stack. Returns 0 if parsing fails.
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	b := bytes.TrimPrefix(buf[:n], []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,89 @@
+package gotrycatch
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ============================================
+// In-flight 测试
+// ============================================
+
+func TestInFlight_BlockedTryIsListed(t *testing.T) {
+	TrackInFlight = true
+	defer func() { TrackInFlight = false }()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		Try(func() {
+			close(started)
+			<-release
+		})
+	}()
+	<-started
+
+	infos := InFlight()
+	if len(infos) != 1 {
+		t.Fatalf("Expected 1 in-flight block, got %d", len(infos))
+	}
+	info := infos[0]
+	if info.GoroutineID == 0 {
+		t.Error("Expected non-zero goroutine id")
+	}
+	if info.GoroutineID == goroutineID() {
+		t.Error("Expected goroutine id of the worker, not the test goroutine")
+	}
+	if !strings.HasSuffix(info.File, "inflight_test.go") {
+		t.Errorf("Expected call site in inflight_test.go, got %s", info.File)
+	}
+	if info.Started.IsZero() || time.Since(info.Started) > time.Minute {
+		t.Errorf("Unexpected start time %v", info.Started)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if n := len(InFlight()); n != 0 {
+		t.Errorf("Expected no in-flight blocks after completion, got %d", n)
+	}
+}
+
+func TestInFlight_RemovedAfterPanic(t *testing.T) {
+	TrackInFlight = true
+	defer func() { TrackInFlight = false }()
+
+	TryWithResult(func() int { panic("boom") })
+	Try(func() { panic("boom") })
+
+	if n := len(InFlight()); n != 0 {
+		t.Errorf("Expected no in-flight blocks after panics, got %d", n)
+	}
+}
+
+func TestInFlight_DisabledByDefault(t *testing.T) {
+	var seen int
+	Try(func() { seen = len(InFlight()) })
+
+	if seen != 0 {
+		t.Errorf("Expected nothing tracked when disabled, got %d", seen)
+	}
+}
+
+func TestGoroutineID(t *testing.T) {
+	id := goroutineID()
+	if id == 0 {
+		t.Fatal("Expected non-zero goroutine id")
+	}
+
+	other := make(chan uint64)
+	go func() { other <- goroutineID() }()
+	if got := <-other; got == id || got == 0 {
+		t.Errorf("Expected a distinct non-zero id for another goroutine, got %d (current %d)", got, id)
+	}
+}
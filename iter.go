@@ -0,0 +1,34 @@
+package gotrycatch
+
+import "fmt"
+
+// ============================================
+// ForEach - Panic-safe iteration
+// ============================================
+
+// ItemError describes an item whose processing panicked in ForEach.
+type ItemError[T any] struct {
+	Index int         // Position of the item in the input slice
+	Value T           // The item that was being processed
+	Err   interface{} // The recovered panic value
+}
+
+// Error implements the error interface.
+func (e ItemError[T]) Error() string {
+	return fmt.Sprintf("item %d (%v): %v", e.Index, e.Value, e.Err)
+}
+
+// ForEach calls fn for every item, recovering panics per item so that one failing
+// item does not stop the iteration. It returns one ItemError per failed item, in order.
+// Returns nil if every item succeeded.
+func ForEach[T any](items []T, fn func(T)) []ItemError[T] {
+	var failures []ItemError[T]
+	for i, item := range items {
+		tb := Try(func() { fn(item) })
+		if tb.HasError() {
+			debugLog("ForEach: item %d panicked with %T", i, tb.err)
+			failures = append(failures, ItemError[T]{Index: i, Value: item, Err: tb.err})
+		}
+	}
+	return failures
+}
@@ -0,0 +1,53 @@
+package gotrycatch
+
+import (
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// ForEach 测试
+// ============================================
+
+func TestForEach_CollectsFailures(t *testing.T) {
+	var processed []int
+	failures := ForEach([]int{1, -2, 3, -4, 5}, func(n int) {
+		if n < 0 {
+			panic(trycatcherrors.NewValidationError("n", "negative", 1001))
+		}
+		processed = append(processed, n)
+	})
+
+	if len(processed) != 3 {
+		t.Errorf("Expected 3 items processed past failures, got %v", processed)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("Expected 2 failures, got %d", len(failures))
+	}
+	if failures[0].Index != 1 || failures[0].Value != -2 {
+		t.Errorf("Expected first failure at index 1 with value -2, got %+v", failures[0])
+	}
+	if failures[1].Index != 3 || failures[1].Value != -4 {
+		t.Errorf("Expected second failure at index 3 with value -4, got %+v", failures[1])
+	}
+	if _, ok := failures[0].Err.(trycatcherrors.ValidationError); !ok {
+		t.Errorf("Expected ValidationError, got %T", failures[0].Err)
+	}
+}
+
+func TestForEach_NoFailures(t *testing.T) {
+	if failures := ForEach([]string{"a", "b"}, func(string) {}); failures != nil {
+		t.Errorf("Expected nil failures, got %v", failures)
+	}
+	if failures := ForEach(nil, func(string) { panic("never") }); failures != nil {
+		t.Errorf("Expected nil failures for empty input, got %v", failures)
+	}
+}
+
+func TestItemError_Error(t *testing.T) {
+	e := ItemError[string]{Index: 2, Value: "x", Err: "bad"}
+	if e.Error() != "item 2 (x): bad" {
+		t.Errorf("Unexpected message: %s", e.Error())
+	}
+}
@@ -0,0 +1,40 @@
+package gotrycatch
+
+import "sync"
+
+// ============================================
+// Memoize - Panic-safe caching of computations
+// ============================================
+
+// Memoize returns a function that caches the results of fn by key. A call that panics
+// returns the zero value and false and is not cached, so the next call with the same
+// key computes again; successful results are cached and returned with true.
+// The returned function is safe for concurrent use. The lock is not held while fn
+// runs, so concurrent calls for an uncached key may compute it more than once.
+func Memoize[K comparable, V any](fn func(K) V) func(K) (V, bool) {
+	var (
+		mu    sync.Mutex
+		cache = make(map[K]V)
+	)
+
+	return func(key K) (V, bool) {
+		mu.Lock()
+		v, ok := cache[key]
+		mu.Unlock()
+		if ok {
+			return v, true
+		}
+
+		tb := TryWithResult(func() V { return fn(key) })
+		if tb.HasError() {
+			debugLog("Memoize: computation for %v panicked with %T, not caching", key, tb.err)
+			var zero V
+			return zero, false
+		}
+
+		mu.Lock()
+		cache[key] = tb.result
+		mu.Unlock()
+		return tb.result, true
+	}
+}
@@ -0,0 +1,63 @@
+package gotrycatch
+
+import (
+	"sync"
+	"testing"
+)
+
+// ============================================
+// Memoize 测试
+// ============================================
+
+func TestMemoize_CachesSuccess(t *testing.T) {
+	calls := map[int]int{}
+	square := Memoize(func(n int) int {
+		calls[n]++
+		return n * n
+	})
+
+	for i := 0; i < 3; i++ {
+		if v, ok := square(4); !ok || v != 16 {
+			t.Errorf("Expected (16, true), got (%d, %v)", v, ok)
+		}
+	}
+	if calls[4] != 1 {
+		t.Errorf("Expected fn to be called once, got %d", calls[4])
+	}
+}
+
+func TestMemoize_PanicNotCached(t *testing.T) {
+	var calls int
+	parse := Memoize(func(s string) int {
+		calls++
+		if s == "bad" {
+			panic("invalid input")
+		}
+		return len(s)
+	})
+
+	for i := 0; i < 2; i++ {
+		if v, ok := parse("bad"); ok || v != 0 {
+			t.Errorf("Expected (0, false), got (%d, %v)", v, ok)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected panicking input to be retried, got %d calls", calls)
+	}
+}
+
+func TestMemoize_Concurrent(t *testing.T) {
+	double := Memoize(func(n int) int { return n * 2 })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			if v, ok := double(n % 5); !ok || v != (n%5)*2 {
+				t.Errorf("Expected %d, got (%d, %v)", (n%5)*2, v, ok)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
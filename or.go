@@ -0,0 +1,89 @@
+package gotrycatch
+
+import "reflect"
+
+// ============================================
+// CatchOr - Fluent fallback chains
+// ============================================
+
+// Handler is a typed catch handler created with On, for use with OrChain.Or and HandlerTable.Add.
+type Handler interface {
+	handle(err interface{}) bool
+	target() string
+}
+
+type typedHandler[T any] func(T)
+
+func (h typedHandler[T]) target() string {
+	return reflect.TypeFor[T]().String()
+}
+
+func (h typedHandler[T]) handle(err interface{}) bool {
+	e, ok := err.(T)
+	if ok {
+		h(e)
+	}
+	return ok
+}
+
+// On wraps a typed handler for OrChain.Or and HandlerTable.Add. The type T is
+// usually inferred from the handler, so no explicit type argument is needed.
+// A nil handler yields a nil Handler, which Or and Add ignore.
+func On[T any](handler func(T)) Handler {
+	if handler == nil {
+		debugLog("On: handler is nil, returning nil Handler")
+		return nil
+	}
+	return typedHandler[T](handler)
+}
+
+// OrChain is a fluent catch chain started by CatchOr.
+//
+// Go methods cannot have type parameters, so a method like Or[U](handler) is not
+// possible. Instead, Or takes a Handler built by the generic function On, which
+// captures the type from the handler's signature:
+//
+//	gotrycatch.CatchOr(tb, func(err errors.ValidationError) { ... }).
+//		Or(gotrycatch.On(func(err errors.DatabaseError) { ... })).
+//		OrAny(func(err interface{}) { ... })
+//
+// As with chained Catch calls, only the first matching handler fires.
+type OrChain struct {
+	tb *TryBlock
+}
+
+// CatchOr handles panics of type T like Catch and returns an OrChain for adding
+// fallback handlers. A nil tb is treated like Catch does.
+func CatchOr[T any](tb *TryBlock, handler func(T)) *OrChain {
+	return &OrChain{tb: Catch[T](tb, handler)}
+}
+
+// Or adds a fallback handler that fires if no earlier handler in the chain matched.
+// A nil handler is ignored.
+func (c *OrChain) Or(h Handler) *OrChain {
+	if h == nil {
+		return c
+	}
+	noteCatchTarget(c.tb, "CatchOr", h.target())
+	if c.tb.err != nil && !c.tb.handled && h.handle(c.tb.err) {
+		debugLog("CatchOr: fallback handler matched %T", c.tb.err)
+		c.tb.handled = true
+	}
+	return c
+}
+
+// OrAny adds a final handler for any value not matched earlier in the chain.
+func (c *OrChain) OrAny(handler func(interface{})) *OrChain {
+	c.tb.CatchAny(handler)
+	return c
+}
+
+// Block returns the underlying TryBlock.
+func (c *OrChain) Block() *TryBlock {
+	return c.tb
+}
+
+// Finally ends the chain like TryBlock.Finally, re-throwing if no handler matched.
+func (c *OrChain) Finally(fn func()) {
+	c.tb.Finally(fn)
+}
@@ -0,0 +1,79 @@
+package gotrycatch
+
+import (
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// CatchOr 测试
+// ============================================
+
+func runOrChain(value interface{}) []string {
+	var fired []string
+	CatchOr(Try(func() { panic(value) }), func(trycatcherrors.ValidationError) {
+		fired = append(fired, "validation")
+	}).
+		Or(On(func(trycatcherrors.DatabaseError) { fired = append(fired, "database") })).
+		Or(On(func(error) { fired = append(fired, "error") })).
+		OrAny(func(interface{}) { fired = append(fired, "any") })
+	return fired
+}
+
+func TestCatchOr_FirstMatchOnly(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{trycatcherrors.NewValidationError("f", "m", 1), "validation"},
+		{trycatcherrors.NewDatabaseError("SELECT", "t", nil), "database"},
+		{trycatcherrors.NewNetworkError("u", 500), "error"},
+		{42, "any"},
+	}
+
+	for _, tt := range tests {
+		fired := runOrChain(tt.value)
+		if len(fired) != 1 || fired[0] != tt.want {
+			t.Errorf("Expected only %q to fire for %T, got %v", tt.want, tt.value, fired)
+		}
+	}
+}
+
+func TestCatchOr_Block(t *testing.T) {
+	chain := CatchOr(Try(func() { panic(42) }), func(string) {}).Or(On(func(float64) {}))
+
+	if chain.Block().IsHandled() {
+		t.Error("Expected no handler to match")
+	}
+	if chain.Or(nil) != chain {
+		t.Error("Expected nil handler to be ignored")
+	}
+}
+
+func TestCatchOr_FinallyRethrows(t *testing.T) {
+	var cleaned bool
+	outer := Try(func() {
+		CatchOr(Try(func() { panic(42) }), func(string) {}).Finally(func() { cleaned = true })
+	})
+
+	if !cleaned || outer.GetError() != 42 {
+		t.Errorf("Expected cleanup and re-throw of 42, got cleaned=%v err=%v", cleaned, outer.GetError())
+	}
+}
+
+func TestCatchOr_NilTypedHandler(t *testing.T) {
+	if On[string](nil) != nil {
+		t.Error("Expected On(nil) to return a nil Handler")
+	}
+
+	var tb *TryBlock
+	if SuppressPanics(func() {
+		tb = CatchOr(Try(func() { panic("boom") }), func(int) {}).Or(On[string](nil)).Block()
+	}) {
+		t.Fatal("Expected a nil typed handler to be ignored, not called")
+	}
+	if tb.IsHandled() {
+		t.Error("Expected the block to stay unhandled")
+	}
+}
@@ -0,0 +1,29 @@
+package gotrycatch
+
+import "fmt"
+
+// ============================================
+// DebugOrdering - Detecting CatchAny-before-typed chains
+// ============================================
+
+// DebugOrdering enables a development check for misordered catch chains. When on,
+// a targeted catch (Catch, CatchChain, CatchOnce, CatchSafe, CatchCtx, Caught,
+// CatchWithReturn, CatchReturnErr, CatchReflect, CatchMap, CatchObserve,
+// CatchStringMatch, CatchCodeRange, CatchRetryable, CatchField, OrChain.Or,
+// Dispatch) that is skipped because an earlier CatchAny already handled the block
+// reports a warning through OrderingWarning. It is off by default and costs nothing
+// when disabled.
+var DebugOrdering = false
+
+// OrderingWarning receives the warnings produced by DebugOrdering.
+// When nil, warnings are written to the debug logger regardless of debug mode.
+var OrderingWarning func(msg string)
+
+func warnOrdering(caller, target string, err interface{}) {
+	msg := fmt.Sprintf("%s: handler for %s skipped because CatchAny already handled %T; move CatchAny to the end of the chain", caller, target, err)
+	if OrderingWarning != nil {
+		OrderingWarning(msg)
+		return
+	}
+	debugLogger.Print(msg)
+}
@@ -0,0 +1,107 @@
+package gotrycatch
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// captureOrderingWarnings enables DebugOrdering for the test and collects warnings.
+func captureOrderingWarnings(t *testing.T) *[]string {
+	t.Helper()
+	var warnings []string
+	DebugOrdering = true
+	OrderingWarning = func(msg string) { warnings = append(warnings, msg) }
+	t.Cleanup(func() {
+		DebugOrdering = false
+		OrderingWarning = nil
+	})
+	return &warnings
+}
+
+// ============================================
+// DebugOrdering 测试
+// ============================================
+
+func TestDebugOrdering_Misordered(t *testing.T) {
+	warnings := captureOrderingWarnings(t)
+
+	var typedCalled bool
+	tb := Try(func() { panic("boom") })
+	tb = tb.CatchAny(func(interface{}) {})
+	tb = Catch[string](tb, func(string) { typedCalled = true })
+
+	if typedCalled {
+		t.Error("Expected typed handler to be skipped")
+	}
+	if len(*warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d: %v", len(*warnings), *warnings)
+	}
+	if !strings.Contains((*warnings)[0], "Catch: handler for string skipped") {
+		t.Errorf("Expected warning to name the skipped catch, got %q", (*warnings)[0])
+	}
+}
+
+func TestDebugOrdering_AllTypedVariants(t *testing.T) {
+	warnings := captureOrderingWarnings(t)
+
+	tb := CatchAnyTyped(Try(func() { panic("boom") }), func(interface{}, string) {})
+	CatchChain[error](tb, func(error) {})
+	CatchOnce[int](tb, func(int) {})
+	Caught[string](tb, func(string) {})
+	CatchReflect(tb, reflect.TypeOf(""), func(interface{}) {})
+
+	if len(*warnings) != 4 {
+		t.Errorf("Expected 4 warnings, got %d: %v", len(*warnings), *warnings)
+	}
+}
+
+func TestDebugOrdering_UntypedVariants(t *testing.T) {
+	warnings := captureOrderingWarnings(t)
+
+	tb := Try(func() { panic("boom") }).CatchAny(func(interface{}) {})
+	CatchMap[string](tb, func(string) interface{} { return nil })
+	CatchObserve[string](tb, func(string) {})
+	CatchStringMatch(tb, "boom", func(string) {})
+	CatchCodeRange(tb, 0, 999, func(interface{}) {})
+	CatchRetryable(tb, func(interface{}) {})
+	CatchField(tb, "*", func(trycatcherrors.ValidationError) {})
+	CatchOr[int](tb, func(int) {}).Or(On(func(string) {}))
+	tb.Dispatch(NewHandlerTable().Add(On(func(string) {})))
+
+	if len(*warnings) != 9 {
+		t.Errorf("Expected 9 warnings, got %d: %v", len(*warnings), *warnings)
+	}
+	if !strings.Contains((*warnings)[0], "CatchMap: handler for string skipped") {
+		t.Errorf("Expected CatchMap warning first, got %q", (*warnings)[0])
+	}
+}
+
+func TestDebugOrdering_CorrectOrder(t *testing.T) {
+	warnings := captureOrderingWarnings(t)
+
+	tb := Try(func() { panic("boom") })
+	tb = Catch[int](tb, func(int) {})
+	tb = Catch[string](tb, func(string) {})
+	tb = Catch[error](tb, func(error) {})
+	tb.CatchAny(func(interface{}) {})
+
+	if len(*warnings) != 0 {
+		t.Errorf("Expected no warnings for a well-ordered chain, got %v", *warnings)
+	}
+}
+
+func TestDebugOrdering_Disabled(t *testing.T) {
+	var warned bool
+	OrderingWarning = func(string) { warned = true }
+	defer func() { OrderingWarning = nil }()
+
+	tb := Try(func() { panic("boom") }).CatchAny(func(interface{}) {})
+	Catch[string](tb, func(string) {})
+
+	if warned {
+		t.Error("Expected no warning when DebugOrdering is off")
+	}
+}
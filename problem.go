@@ -0,0 +1,275 @@
+package gotrycatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// ProblemJSON - RFC 7807 problem details
+// ============================================
+
+// ProblemContentType is the media type for problem details documents.
+const ProblemContentType = "application/problem+json"
+
+// defaultStatuses maps the TypeName of known error values to the HTTP status
+// reported by HTTPStatusFor. Pointer types resolve to the same status as their
+// value types.
+var defaultStatuses = map[string]int{
+	"errors.ValidationError":      http.StatusBadRequest,
+	"errors.ValidationErrors":     http.StatusBadRequest,
+	"errors.ValidationTree":       http.StatusBadRequest,
+	"errors.AuthError":            http.StatusUnauthorized,
+	"errors.BusinessLogicError":   http.StatusUnprocessableEntity,
+	"errors.RateLimitError":       http.StatusTooManyRequests,
+	"errors.NetworkError":         http.StatusBadGateway,
+	"errors.DatabaseError":        http.StatusInternalServerError,
+	"errors.BatchDatabaseError":   http.StatusInternalServerError,
+	"errors.ConfigError":          http.StatusInternalServerError,
+	"gotrycatch.CircuitOpenError": http.StatusServiceUnavailable,
+}
+
+// statusOverrides holds the mapping loaded by LoadStatusMap, consulted before defaultStatuses.
+var (
+	statusMu        sync.RWMutex
+	statusOverrides map[string]int
+)
+
+// StatusMapWarning is returned by LoadStatusMap when the map names types that
+// HTTPStatusFor does not know. The remaining entries are still applied.
+type StatusMapWarning struct {
+	Unknown []string // Ignored type names, sorted
+}
+
+func (w StatusMapWarning) Error() string {
+	return fmt.Sprintf("status map: ignored unknown type name(s): %s", strings.Join(w.Unknown, ", "))
+}
+
+// LoadStatusMap reads a JSON object mapping type names to HTTP status codes and uses
+// it to override the defaults of HTTPStatusFor, e.g. {"errors.AuthError": 403}.
+// Type names are those returned by TypeName, without a leading "*". Each call replaces
+// the overrides of the previous one, so loading {} restores the defaults.
+//
+// Malformed JSON or a status outside 100-599 is an error and leaves the current
+// mapping untouched. Unknown type names are ignored and reported with a StatusMapWarning.
+func LoadStatusMap(r io.Reader) error {
+	var m map[string]int
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return fmt.Errorf("status map: %w", err)
+	}
+
+	overrides := make(map[string]int, len(m))
+	var unknown []string
+	for name, status := range m {
+		if status < 100 || status > 599 {
+			return fmt.Errorf("status map: invalid status %d for %s", status, name)
+		}
+		if _, known := defaultStatuses[name]; !known {
+			unknown = append(unknown, name)
+			continue
+		}
+		overrides[name] = status
+	}
+
+	statusMu.Lock()
+	statusOverrides = overrides
+	statusMu.Unlock()
+	debugLog("LoadStatusMap: loaded %d override(s), ignored %d", len(overrides), len(unknown))
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return StatusMapWarning{Unknown: unknown}
+	}
+	return nil
+}
+
+// internalProblemKeys are ToMap keys that describe where an error was raised or
+// carry raw driver errors. They are useful in logs but must not leak to HTTP clients.
+var internalProblemKeys = map[string]bool{
+	"type":      true,
+	"file":      true,
+	"line":      true,
+	"function":  true,
+	"timestamp": true,
+	"stack":     true,
+	"headers":   true,
+	"cause":     true,
+	"rowErrors": true,
+}
+
+// HTTPStatusFor returns the HTTP status code that best describes err.
+// Built-in error types map to a default status (ValidationError is 400, RateLimitError
+// is 429, and so on), which LoadStatusMap can override. A nil error maps to 200 and
+// any other value maps to 500.
+func HTTPStatusFor(err interface{}) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	name := strings.TrimPrefix(TypeName(err), "*")
+	statusMu.RLock()
+	status, ok := statusOverrides[name]
+	statusMu.RUnlock()
+	if ok {
+		return status
+	}
+	if status, ok := defaultStatuses[name]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// ProblemJSON renders err as an RFC 7807 problem details document and returns it
+// together with its HTTP status. The document always has type, title, status and
+// detail members. For client errors (status below 500), fields from the error's ToMap
+// method, such as field and code for ValidationError, are added as extension members,
+// minus source locations, stacks and headers. Server errors get no extension members,
+// since their fields (tables, config values, URLs) describe internals rather than the
+// request. For the same reason detail never uses Error(), which carries the source
+// location and wrapped causes; see problemDetail for what it contains instead.
+func ProblemJSON(err interface{}) ([]byte, int) {
+	status := HTTPStatusFor(err)
+
+	detail := problemDetail(err, status)
+
+	problem := map[string]interface{}{}
+	if mapper, ok := err.(interface{ ToMap() map[string]interface{} }); ok && status < http.StatusInternalServerError {
+		for k, v := range sanitizeProblemMap(mapper.ToMap()) {
+			problem[k] = v
+		}
+	}
+
+	problem["type"] = problemType(err)
+	problem["title"] = http.StatusText(status)
+	problem["status"] = status
+	problem["detail"] = detail
+
+	data, marshalErr := json.Marshal(problem)
+	if marshalErr != nil {
+		debugLog("ProblemJSON: failed to marshal %T: %v", err, marshalErr)
+		data, _ = json.Marshal(map[string]interface{}{
+			"type":   "about:blank",
+			"title":  http.StatusText(status),
+			"status": status,
+			"detail": detail,
+		})
+	}
+	return data, status
+}
+
+// problemType returns a URI identifying the kind of problem. Structured errors
+// (those with a ToMap method or a known status) get a URN built from their type
+// name; anything else is reported as about:blank, as RFC 7807 recommends.
+func problemType(err interface{}) string {
+	name := strings.TrimPrefix(TypeName(err), "*")
+	_, known := defaultStatuses[name]
+	_, structured := err.(interface{ ToMap() map[string]interface{} })
+	if !known && !structured {
+		return "about:blank"
+	}
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return "urn:gotrycatch:error:" + name
+}
+
+// problemDetail returns a client-safe explanation of err for the detail member.
+// Client errors use the human-readable field of the built-in types (Message for
+// validation failures, Details for business rules, Reason for auth and config errors).
+// Server errors and values without such a field get the status text, so driver
+// errors and runtime panic messages stay out of responses.
+func problemDetail(err interface{}, status int) string {
+	if status < http.StatusInternalServerError {
+		if ve, ok := ValueOf[trycatcherrors.ValidationError](err); ok {
+			return ve.Message
+		}
+		if ves, ok := ValueOf[trycatcherrors.ValidationErrors](err); ok {
+			return validationDetail(ves)
+		}
+		if tree, ok := err.(*trycatcherrors.ValidationTree); ok && tree != nil {
+			return validationDetail(tree.Flatten())
+		}
+		if be, ok := ValueOf[trycatcherrors.BusinessLogicError](err); ok {
+			return be.Details
+		}
+		if ae, ok := ValueOf[trycatcherrors.AuthError](err); ok {
+			return ae.Reason
+		}
+		if ce, ok := ValueOf[trycatcherrors.ConfigError](err); ok {
+			return ce.Reason
+		}
+	}
+	return http.StatusText(status)
+}
+
+// validationDetail summarizes validation failures as "field: message" pairs.
+func validationDetail(errs []trycatcherrors.ValidationError) string {
+	if len(errs) == 1 {
+		return errs[0].Message
+	}
+	msgs := make([]string, len(errs))
+	for i, ve := range errs {
+		msgs[i] = ve.Field + ": " + ve.Message
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(errs), strings.Join(msgs, "; "))
+}
+
+// errorMessage returns the message of err: Error() for errors, fmt.Sprint otherwise.
+func errorMessage(err interface{}) string {
+	switch e := err.(type) {
+	case nil:
+		return ""
+	case error:
+		return e.Error()
+	default:
+		return fmt.Sprint(e)
+	}
+}
+
+// sanitizeProblemMap removes internal keys from m, including those of nested
+// maps such as the entries of ValidationErrors.
+func sanitizeProblemMap(m map[string]interface{}) map[string]interface{} {
+	clean := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if internalProblemKeys[k] {
+			continue
+		}
+		if nested, ok := v.([]map[string]interface{}); ok {
+			items := make([]map[string]interface{}, len(nested))
+			for i, item := range nested {
+				items[i] = sanitizeProblemMap(item)
+			}
+			v = items
+		}
+		clean[k] = v
+	}
+	return clean
+}
+
+// HandlerFunc wraps an HTTP handler so that a panic is recovered and answered with
+// the problem details document of the panic value, using the status from HTTPStatusFor.
+// It protects a single handler where installing a global recovery middleware is not
+// wanted. http.ErrAbortHandler is re-panicked so net/http can abort the response.
+func HandlerFunc(fn func(http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		panicked, value := TryFast(func() { fn(w, r) })
+		if !panicked {
+			return
+		}
+		if value == http.ErrAbortHandler {
+			panic(value)
+		}
+
+		body, status := ProblemJSON(value)
+		debugLog("HandlerFunc: %s %s panicked with %T, responding %d", r.Method, r.URL.Path, value, status)
+		w.Header().Set("Content-Type", ProblemContentType)
+		w.WriteHeader(status)
+		w.Write(body)
+	}
+}
@@ -0,0 +1,341 @@
+package gotrycatch
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// HTTPStatusFor 测试
+// ============================================
+
+func TestHTTPStatusFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  interface{}
+		want int
+	}{
+		{"nil", nil, http.StatusOK},
+		{"validation", trycatcherrors.NewValidationError("f", "m", 1), http.StatusBadRequest},
+		{"validation pointer", &trycatcherrors.ValidationError{}, http.StatusBadRequest},
+		{"validation tree", trycatcherrors.NewValidationTree(), http.StatusBadRequest},
+		{"auth", trycatcherrors.NewAuthError("login", "bob", "bad password"), http.StatusUnauthorized},
+		{"rate limit", trycatcherrors.NewRateLimitError("api", 10, 11, 30), http.StatusTooManyRequests},
+		{"circuit open", CircuitOpenError{}, http.StatusServiceUnavailable},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError},
+		{"string", "boom", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTTPStatusFor(tt.err); got != tt.want {
+				t.Errorf("Expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+// ============================================
+// ProblemJSON 测试
+// ============================================
+
+func decodeProblem(t *testing.T, data []byte) map[string]interface{} {
+	t.Helper()
+	var problem map[string]interface{}
+	if err := json.Unmarshal(data, &problem); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %s", err, data)
+	}
+	for _, key := range []string{"type", "title", "status", "detail"} {
+		if _, ok := problem[key]; !ok {
+			t.Errorf("Expected required member %q in %s", key, data)
+		}
+	}
+	for _, key := range []string{"file", "line", "function", "stack", "timestamp"} {
+		if _, ok := problem[key]; ok {
+			t.Errorf("Expected internal member %q to be omitted from %s", key, data)
+		}
+	}
+	return problem
+}
+
+func TestProblemJSON_BuiltinTypes(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+		wantType   string
+		wantDetail string
+	}{
+		{trycatcherrors.NewValidationError("email", "invalid", 1001), 400, "urn:gotrycatch:error:ValidationError", "invalid"},
+		{trycatcherrors.ValidationErrors{trycatcherrors.NewValidationError("a", "b", 1), trycatcherrors.NewValidationError("c", "d", 2)}, 400, "urn:gotrycatch:error:ValidationErrors", "2 validation errors: a: b; c: d"},
+		{trycatcherrors.NewDatabaseError("SELECT", "users", errors.New("pq: connection reset")), 500, "urn:gotrycatch:error:DatabaseError", "Internal Server Error"},
+		{trycatcherrors.NewBatchDatabaseError("INSERT", "users", map[int]error{0: errors.New("dup")}), 500, "urn:gotrycatch:error:BatchDatabaseError", "Internal Server Error"},
+		{trycatcherrors.NewNetworkError("https://api.example.com", 503), 502, "urn:gotrycatch:error:NetworkError", "Bad Gateway"},
+		{trycatcherrors.NewBusinessLogicError("rule", "details"), 422, "urn:gotrycatch:error:BusinessLogicError", "details"},
+		{trycatcherrors.NewConfigError("key", "value", "reason"), 500, "urn:gotrycatch:error:ConfigError", "Internal Server Error"},
+		{trycatcherrors.NewAuthError("login", "bob", "reason"), 401, "urn:gotrycatch:error:AuthError", "reason"},
+		{trycatcherrors.NewRateLimitError("api", 10, 11, 30), 429, "urn:gotrycatch:error:RateLimitError", "Too Many Requests"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantType, func(t *testing.T) {
+			data, status := ProblemJSON(tt.err)
+			if status != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, status)
+			}
+
+			problem := decodeProblem(t, data)
+			if problem["type"] != tt.wantType {
+				t.Errorf("Expected type %s, got %v", tt.wantType, problem["type"])
+			}
+			if problem["status"] != float64(tt.wantStatus) {
+				t.Errorf("Expected status member %d, got %v", tt.wantStatus, problem["status"])
+			}
+			if problem["title"] != http.StatusText(tt.wantStatus) {
+				t.Errorf("Expected title %q, got %v", http.StatusText(tt.wantStatus), problem["title"])
+			}
+			if problem["detail"] != tt.wantDetail {
+				t.Errorf("Expected detail %q, got %v", tt.wantDetail, problem["detail"])
+			}
+			if strings.Contains(string(data), ".go:") || strings.Contains(string(data), "pq:") || strings.Contains(string(data), "dup") {
+				t.Errorf("Expected no source locations or driver errors in %s", data)
+			}
+		})
+	}
+}
+
+func TestProblemJSON_Extensions(t *testing.T) {
+	data, _ := ProblemJSON(trycatcherrors.NewValidationError("email", "invalid", 1001))
+	problem := decodeProblem(t, data)
+
+	if problem["field"] != "email" {
+		t.Errorf("Expected field extension 'email', got %v", problem["field"])
+	}
+	if problem["code"] != float64(1001) {
+		t.Errorf("Expected code extension 1001, got %v", problem["code"])
+	}
+}
+
+func TestProblemJSON_NestedValidationErrorsSanitized(t *testing.T) {
+	data, _ := ProblemJSON(trycatcherrors.ValidationErrors{
+		trycatcherrors.NewValidationError("a", "required", 1),
+		trycatcherrors.NewValidationError("b", "too long", 2),
+	})
+	problem := decodeProblem(t, data)
+
+	entries, ok := problem["errors"].([]interface{})
+	if !ok || len(entries) != 2 {
+		t.Fatalf("Expected 2 nested errors, got %v", problem["errors"])
+	}
+	for _, entry := range entries {
+		if _, leaked := entry.(map[string]interface{})["stack"]; leaked {
+			t.Error("Expected nested stack to be omitted")
+		}
+	}
+}
+
+func TestProblemJSON_ServerErrorsOmitExtensions(t *testing.T) {
+	for _, err := range []error{
+		trycatcherrors.NewConfigError("database.dsn", "postgres://admin:hunter2@db/prod", "unreachable"),
+		trycatcherrors.NewDatabaseError("SELECT", "payroll", nil),
+	} {
+		data, status := ProblemJSON(err)
+		if status != http.StatusInternalServerError {
+			t.Fatalf("Expected 500 for %T, got %d", err, status)
+		}
+		body := string(data)
+		for _, leaked := range []string{"hunter2", "database.dsn", "unreachable", "payroll", "SELECT"} {
+			if strings.Contains(body, leaked) {
+				t.Errorf("Expected %q to be absent from %s", leaked, body)
+			}
+		}
+		if problem := decodeProblem(t, data); len(problem) != 4 {
+			t.Errorf("Expected only type, title, status and detail, got %v", problem)
+		}
+	}
+}
+
+func TestProblemJSON_ValidationTree(t *testing.T) {
+	tree := trycatcherrors.NewValidationTree()
+	tree.AddAt("items.0", trycatcherrors.NewValidationError("quantity", "must be positive", 1002))
+
+	data, status := ProblemJSON(tree)
+	problem := decodeProblem(t, data)
+
+	if status != http.StatusBadRequest || problem["type"] != "urn:gotrycatch:error:ValidationTree" {
+		t.Errorf("Expected 400 ValidationTree problem, got %d %v", status, problem["type"])
+	}
+	if problem["detail"] != "must be positive" {
+		t.Errorf("Expected validation message as detail, got %v", problem["detail"])
+	}
+	entries, ok := problem["errors"].([]interface{})
+	if !ok || len(entries) != 1 || entries[0].(map[string]interface{})["field"] != "items.0.quantity" {
+		t.Errorf("Expected flattened entry for items.0.quantity, got %v", problem["errors"])
+	}
+}
+
+func TestProblemJSON_PlainValues(t *testing.T) {
+	data, status := ProblemJSON("something broke")
+	problem := decodeProblem(t, data)
+
+	if status != http.StatusInternalServerError {
+		t.Errorf("Expected 500, got %d", status)
+	}
+	if problem["type"] != "about:blank" {
+		t.Errorf("Expected about:blank, got %v", problem["type"])
+	}
+	if problem["detail"] != "Internal Server Error" {
+		t.Errorf("Expected status text as detail for a plain value, got %v", problem["detail"])
+	}
+
+	_, status = ProblemJSON(CircuitOpenError{OpenedAt: time.Now(), RetryAt: time.Now()})
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 for CircuitOpenError, got %d", status)
+	}
+}
+
+// ============================================
+// LoadStatusMap 测试
+// ============================================
+
+func TestLoadStatusMap_Overrides(t *testing.T) {
+	defer LoadStatusMap(strings.NewReader("{}"))
+
+	err := LoadStatusMap(strings.NewReader(`{"errors.AuthError": 403, "errors.NetworkError": 504}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := HTTPStatusFor(trycatcherrors.NewAuthError("login", "bob", "denied")); got != http.StatusForbidden {
+		t.Errorf("Expected overridden 403, got %d", got)
+	}
+	if got := HTTPStatusFor(&trycatcherrors.NetworkError{}); got != http.StatusGatewayTimeout {
+		t.Errorf("Expected overridden 504 for pointer form, got %d", got)
+	}
+	if got := HTTPStatusFor(trycatcherrors.NewValidationError("f", "m", 1)); got != http.StatusBadRequest {
+		t.Errorf("Expected default 400 to remain, got %d", got)
+	}
+	if _, status := ProblemJSON(trycatcherrors.NewAuthError("login", "bob", "denied")); status != http.StatusForbidden {
+		t.Errorf("Expected ProblemJSON to use the override, got %d", status)
+	}
+
+	if err := LoadStatusMap(strings.NewReader("{}")); err != nil {
+		t.Fatalf("Expected no error resetting, got %v", err)
+	}
+	if got := HTTPStatusFor(trycatcherrors.NewAuthError("login", "bob", "denied")); got != http.StatusUnauthorized {
+		t.Errorf("Expected default 401 after reset, got %d", got)
+	}
+}
+
+func TestLoadStatusMap_UnknownTypeWarning(t *testing.T) {
+	defer LoadStatusMap(strings.NewReader("{}"))
+
+	err := LoadStatusMap(strings.NewReader(`{"errors.ConfigError": 503, "main.Missing": 418, "Bogus": 400}`))
+
+	var warning StatusMapWarning
+	if !errors.As(err, &warning) {
+		t.Fatalf("Expected StatusMapWarning, got %v", err)
+	}
+	if len(warning.Unknown) != 2 || warning.Unknown[0] != "Bogus" || warning.Unknown[1] != "main.Missing" {
+		t.Errorf("Expected sorted unknown names, got %v", warning.Unknown)
+	}
+	if got := HTTPStatusFor(trycatcherrors.NewConfigError("k", "v", "r")); got != http.StatusServiceUnavailable {
+		t.Errorf("Expected known entries to apply despite warning, got %d", got)
+	}
+}
+
+func TestLoadStatusMap_Invalid(t *testing.T) {
+	defer LoadStatusMap(strings.NewReader("{}"))
+	LoadStatusMap(strings.NewReader(`{"errors.AuthError": 403}`))
+
+	for _, input := range []string{`not json`, `{"errors.AuthError": 42}`} {
+		err := LoadStatusMap(strings.NewReader(input))
+		if err == nil {
+			t.Errorf("Expected error for %s", input)
+		}
+		var warning StatusMapWarning
+		if errors.As(err, &warning) {
+			t.Errorf("Expected a hard error for %s, got warning", input)
+		}
+	}
+
+	if got := HTTPStatusFor(trycatcherrors.NewAuthError("o", "u", "r")); got != http.StatusForbidden {
+		t.Errorf("Expected previous mapping to survive invalid input, got %d", got)
+	}
+}
+
+// ============================================
+// HandlerFunc 测试
+// ============================================
+
+func TestHandlerFunc_PanicWritesProblem(t *testing.T) {
+	handler := HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Throw(trycatcherrors.NewValidationError("email", "invalid format", 1001))
+	})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("Expected problem content type, got %q", ct)
+	}
+	var problem map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Expected JSON body, got %q: %v", rec.Body.String(), err)
+	}
+	if problem["field"] != "email" || problem["status"] != float64(http.StatusBadRequest) {
+		t.Errorf("Expected validation problem for email, got %v", problem)
+	}
+}
+
+func TestHandlerFunc_RuntimePanicHidesMessage(t *testing.T) {
+	handler := HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var items []int
+		_ = items[3]
+	})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	problem := decodeProblem(t, rec.Body.Bytes())
+	if rec.Code != http.StatusInternalServerError || problem["detail"] != "Internal Server Error" {
+		t.Errorf("Expected generic 500 problem, got %d %v", rec.Code, problem)
+	}
+}
+
+func TestHandlerFunc_NoPanicPassesThrough(t *testing.T) {
+	handler := HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("ok"))
+	})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusCreated || rec.Body.String() != "ok" {
+		t.Errorf("Expected untouched response, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandlerFunc_AbortHandlerRepanics(t *testing.T) {
+	handler := HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	tb := Try(func() {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+
+	if tb.GetError() != http.ErrAbortHandler {
+		t.Errorf("Expected ErrAbortHandler to propagate, got %v", tb.GetError())
+	}
+}
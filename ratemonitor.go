@@ -0,0 +1,89 @@
+package gotrycatch
+
+import (
+	"sync"
+	"time"
+)
+
+// ============================================
+// RateMonitor - Panic rate over a moving window
+// ============================================
+
+// RateMonitor records panic timestamps and reports the panic rate over a moving window,
+// for alerting on failure surges. Timestamps older than the retention period are
+// discarded, so windows longer than the retention undercount.
+// A RateMonitor is safe for concurrent use.
+//
+// Observe has the shape of a catch handler, so a monitor can be attached to any chain:
+//
+//	tb.CatchAny(monitor.Observe)
+//	gotrycatch.CatchObserve[errors.NetworkError](tb, func(e errors.NetworkError) { monitor.Observe(e) })
+type RateMonitor struct {
+	retention time.Duration
+	now       func() time.Time // injectable clock for tests
+
+	mu     sync.Mutex
+	events []time.Time
+}
+
+// NewRateMonitor creates a monitor that keeps panic timestamps for retention.
+func NewRateMonitor(retention time.Duration) *RateMonitor {
+	return &RateMonitor{
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Record notes one panic at the current time.
+func (m *RateMonitor) Record() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.events = append(m.events, now)
+	m.prune(now)
+}
+
+// Observe records a panic for err. Nil values are ignored.
+func (m *RateMonitor) Observe(err interface{}) {
+	if err == nil {
+		return
+	}
+	m.Record()
+}
+
+// Rate returns the number of panics per second within the last window.
+// Returns 0 for a non-positive window.
+func (m *RateMonitor) Rate(window time.Duration) float64 {
+	if window <= 0 {
+		return 0
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.prune(now)
+	cutoff := now.Add(-window)
+	var count int
+	for i := len(m.events) - 1; i >= 0 && m.events[i].After(cutoff); i-- {
+		count++
+	}
+	return float64(count) / window.Seconds()
+}
+
+// Exceeds reports whether the panic rate within the last window is above threshold
+// panics per second.
+func (m *RateMonitor) Exceeds(threshold float64, window time.Duration) bool {
+	return m.Rate(window) > threshold
+}
+
+// prune drops events older than the retention period. Callers must hold m.mu.
+func (m *RateMonitor) prune(now time.Time) {
+	cutoff := now.Add(-m.retention)
+	i := 0
+	for i < len(m.events) && !m.events[i].After(cutoff) {
+		i++
+	}
+	m.events = m.events[i:]
+}
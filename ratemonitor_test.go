@@ -0,0 +1,78 @@
+package gotrycatch
+
+import (
+	"testing"
+	"time"
+)
+
+// ============================================
+// RateMonitor 测试
+// ============================================
+
+func TestRateMonitor_Rate(t *testing.T) {
+	clock := newFakeClock()
+	m := NewRateMonitor(time.Minute)
+	m.now = clock.Now
+
+	// 10 panics spread over the first 10 seconds, then 5 in the last second.
+	for i := 0; i < 10; i++ {
+		m.Record()
+		clock.Advance(time.Second)
+	}
+	for i := 0; i < 5; i++ {
+		m.Record()
+	}
+
+	if got := m.Rate(time.Second); got != 5 {
+		t.Errorf("Expected 5/s over the last second, got %v", got)
+	}
+	if got := m.Rate(20 * time.Second); got != 0.75 {
+		t.Errorf("Expected 15 panics / 20s = 0.75/s, got %v", got)
+	}
+	if !m.Exceeds(4, time.Second) || m.Exceeds(1, 20*time.Second) {
+		t.Error("Unexpected Exceeds result")
+	}
+}
+
+func TestRateMonitor_WindowSlides(t *testing.T) {
+	clock := newFakeClock()
+	m := NewRateMonitor(time.Minute)
+	m.now = clock.Now
+
+	m.Observe("boom")
+	m.Observe(nil)
+	clock.Advance(2 * time.Second)
+
+	if got := m.Rate(time.Second); got != 0 {
+		t.Errorf("Expected old panic to fall out of a 1s window, got %v", got)
+	}
+	if got := m.Rate(10 * time.Second); got != 0.1 {
+		t.Errorf("Expected 1 panic / 10s, got %v", got)
+	}
+}
+
+func TestRateMonitor_Retention(t *testing.T) {
+	clock := newFakeClock()
+	m := NewRateMonitor(time.Minute)
+	m.now = clock.Now
+
+	m.Record()
+	clock.Advance(2 * time.Minute)
+	m.Record()
+
+	if len(m.events) != 1 {
+		t.Errorf("Expected events older than retention to be pruned, got %d", len(m.events))
+	}
+}
+
+func TestRateMonitor_WithCatchAny(t *testing.T) {
+	m := NewRateMonitor(time.Minute)
+
+	Try(func() { panic("a") }).CatchAny(m.Observe)
+	Try(func() { panic("b") }).CatchAny(m.Observe)
+	Try(func() {}).CatchAny(m.Observe)
+
+	if got := m.Rate(time.Minute) * 60; got < 1.99 || got > 2.01 {
+		t.Errorf("Expected 2 panics in the last minute, got %v", got)
+	}
+}
@@ -0,0 +1,154 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ============================================
+// FormatReport - Human-readable failure summaries
+// ============================================
+
+// ReportGroupBy selects how FormatReport groups errors.
+type ReportGroupBy int
+
+const (
+	// ReportBySeverity groups errors by SeverityOf, most severe first.
+	ReportBySeverity ReportGroupBy = iota
+	// ReportByType groups errors by TypeName, largest group first.
+	ReportByType
+)
+
+// defaultReportSamples is the number of sample messages per group when
+// ReportOptions.MaxSamples is zero.
+const defaultReportSamples = 3
+
+// ReportOptions configures FormatReport.
+type ReportOptions struct {
+	GroupBy    ReportGroupBy // Grouping key
+	MaxSamples int           // Distinct sample messages per group; 0 means 3, negative means none
+	Color      bool          // Color group headers with ANSI escapes, for terminals
+}
+
+// ANSI escapes used when ReportOptions.Color is set.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// severityColors are the header colors of severity groups.
+var severityColors = map[Severity]string{
+	SeverityCritical: ansiBold + ansiRed,
+	SeverityError:    ansiRed,
+	SeverityWarning:  ansiYellow,
+	SeverityInfo:     ansiCyan,
+}
+
+// reportGroup is one group of a FormatReport summary.
+type reportGroup struct {
+	name     string
+	severity Severity
+	errs     []interface{}
+}
+
+// FormatReport summarizes errs as a grouped, human-readable report for CLI output and
+// batch job logs. Each group lists its count and up to MaxSamples distinct messages,
+// formatted with Describe:
+//
+//	5 error(s) in 2 group(s)
+//	critical (2)
+//	  - database error during INSERT on users ...
+//	warning (3)
+//	  - validation failed for field 'email' ...
+//	  ... and 1 more
+//
+// Severity groups are ordered from most to least severe; type groups by size, then
+// name. Returns "no errors\n" for an empty slice.
+func FormatReport(errs []interface{}, opts ReportOptions) string {
+	if len(errs) == 0 {
+		return "no errors\n"
+	}
+
+	groups := groupReport(errs, opts.GroupBy)
+	samples := opts.MaxSamples
+	if samples == 0 {
+		samples = defaultReportSamples
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d error(s) in %d group(s)\n", len(errs), len(groups))
+	for _, g := range groups {
+		header := fmt.Sprintf("%s (%d)", g.name, len(g.errs))
+		if opts.Color {
+			color := ansiBold
+			if opts.GroupBy == ReportBySeverity {
+				color = severityColors[g.severity]
+			}
+			header = color + header + ansiReset
+		}
+		b.WriteString(header + "\n")
+
+		messages := distinctMessages(g.errs)
+		shown := 0
+		for _, msg := range messages {
+			if shown >= samples {
+				break
+			}
+			fmt.Fprintf(&b, "  - %s\n", msg)
+			shown++
+		}
+		if rest := len(messages) - shown; rest > 0 && samples >= 0 {
+			fmt.Fprintf(&b, "  ... and %d more\n", rest)
+		}
+	}
+	return b.String()
+}
+
+// groupReport partitions errs by the grouping key, in report order.
+func groupReport(errs []interface{}, by ReportGroupBy) []*reportGroup {
+	var groups []*reportGroup
+	index := make(map[string]*reportGroup)
+	for _, err := range errs {
+		s := SeverityOf(err)
+		name := s.String()
+		if by == ReportByType {
+			name = TypeName(err)
+		}
+		g, ok := index[name]
+		if !ok {
+			g = &reportGroup{name: name, severity: s}
+			index[name] = g
+			groups = append(groups, g)
+		}
+		g.errs = append(g.errs, err)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if by == ReportBySeverity {
+			return groups[i].severity > groups[j].severity
+		}
+		if len(groups[i].errs) != len(groups[j].errs) {
+			return len(groups[i].errs) > len(groups[j].errs)
+		}
+		return groups[i].name < groups[j].name
+	})
+	return groups
+}
+
+// distinctMessages returns the distinct descriptions of errs, in first-seen order.
+func distinctMessages(errs []interface{}) []string {
+	var messages []string
+	seen := make(map[string]bool)
+	for _, err := range errs {
+		msg := Describe(err)
+		if !seen[msg] {
+			seen[msg] = true
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
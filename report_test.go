@@ -0,0 +1,76 @@
+package gotrycatch
+
+import (
+	"strings"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// FormatReport 测试
+// ============================================
+
+func reportSample() []interface{} {
+	return []interface{}{
+		trycatcherrors.NewValidationError("email", "invalid", 1001),
+		"disk full",
+		trycatcherrors.NewDatabaseError("INSERT", "users", nil),
+		"disk full",
+		"quota exceeded",
+		trycatcherrors.NewValidationError("name", "required", 1002),
+	}
+}
+
+func TestFormatReport_BySeverity(t *testing.T) {
+	report := FormatReport(reportSample(), ReportOptions{MaxSamples: 1})
+
+	lines := strings.Split(strings.TrimSuffix(report, "\n"), "\n")
+	if lines[0] != "6 error(s) in 3 group(s)" {
+		t.Errorf("Unexpected summary line %q", lines[0])
+	}
+
+	critical := strings.Index(report, "critical (1)")
+	errorGroup := strings.Index(report, "error (3)")
+	warning := strings.Index(report, "warning (2)")
+	if critical < 0 || errorGroup < critical || warning < errorGroup {
+		t.Errorf("Expected groups critical, error, warning in order, got:\n%s", report)
+	}
+	if !strings.Contains(report, "  - disk full\n  ... and 1 more\n") {
+		t.Errorf("Expected one distinct sample and a remainder, got:\n%s", report)
+	}
+	if strings.Contains(report, "\x1b[") {
+		t.Error("Expected no color escapes without Color")
+	}
+}
+
+func TestFormatReport_ByType(t *testing.T) {
+	report := FormatReport(reportSample(), ReportOptions{GroupBy: ReportByType})
+
+	str := strings.Index(report, "string (3)")
+	validation := strings.Index(report, "errors.ValidationError (2)")
+	database := strings.Index(report, "errors.DatabaseError (1)")
+	if str < 0 || validation < str || database < validation {
+		t.Errorf("Expected type groups by size, got:\n%s", report)
+	}
+	if strings.Count(report, "disk full") != 1 || !strings.Contains(report, "quota exceeded") {
+		t.Errorf("Expected distinct samples, got:\n%s", report)
+	}
+}
+
+func TestFormatReport_ColorAndNoSamples(t *testing.T) {
+	report := FormatReport(reportSample(), ReportOptions{MaxSamples: -1, Color: true})
+
+	if !strings.Contains(report, ansiBold+ansiRed+"critical (1)"+ansiReset) {
+		t.Errorf("Expected colored critical header, got %q", report)
+	}
+	if strings.Contains(report, "  - ") || strings.Contains(report, "more") {
+		t.Errorf("Expected no samples, got %q", report)
+	}
+}
+
+func TestFormatReport_Empty(t *testing.T) {
+	if got := FormatReport(nil, ReportOptions{}); got != "no errors\n" {
+		t.Errorf("Expected 'no errors', got %q", got)
+	}
+}
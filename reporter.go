@@ -0,0 +1,75 @@
+package gotrycatch
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// ============================================
+// Reporter - Streaming error log with counts
+// ============================================
+
+// ReportRecord is the structured line written by Reporter for each reported error.
+type ReportRecord struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Type        string    `json:"type"`
+	Fingerprint string    `json:"fingerprint"`
+	Message     string    `json:"message"`
+}
+
+// Reporter writes one JSON line per reported error and counts errors by Fingerprint.
+// It is intended for batch jobs that produce many failures: the stream gives a compact
+// log and Summary gives aggregate counts. A Reporter is safe for concurrent use.
+type Reporter struct {
+	now func() time.Time // injectable clock for tests
+
+	mu     sync.Mutex
+	enc    *json.Encoder
+	counts map[string]int
+}
+
+// NewReporter creates a Reporter writing to w.
+func NewReporter(w io.Writer) *Reporter {
+	return &Reporter{
+		now:    time.Now,
+		enc:    json.NewEncoder(w),
+		counts: make(map[string]int),
+	}
+}
+
+// Report writes a record for err and counts it. Nil values are ignored.
+// Write failures are logged in debug mode and do not affect the counts.
+func (r *Reporter) Report(err interface{}) {
+	if err == nil {
+		return
+	}
+
+	record := ReportRecord{
+		Timestamp:   r.now(),
+		Type:        TypeName(err),
+		Fingerprint: Fingerprint(err),
+		Message:     Describe(err),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[record.Fingerprint]++
+	if writeErr := r.enc.Encode(record); writeErr != nil {
+		debugLog("Reporter: failed to write record for %s: %v", record.Type, writeErr)
+	}
+}
+
+// Summary returns the number of reported errors per fingerprint.
+// The returned map is a copy and can be modified freely.
+func (r *Reporter) Summary() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summary := make(map[string]int, len(r.counts))
+	for k, v := range r.counts {
+		summary[k] = v
+	}
+	return summary
+}
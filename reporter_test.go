@@ -0,0 +1,74 @@
+package gotrycatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Reporter 测试
+// ============================================
+
+func TestReporter_LinesAndSummary(t *testing.T) {
+	var buf bytes.Buffer
+	clock := newFakeClock()
+	r := NewReporter(&buf)
+	r.now = clock.Now
+
+	first := trycatcherrors.NewValidationError("email", "invalid", 1001)
+	again := trycatcherrors.NewValidationError("email", "still invalid", 1001)
+	other := trycatcherrors.NewDatabaseError("SELECT", "users", nil)
+
+	r.Report(first)
+	r.Report(again)
+	r.Report(other)
+	r.Report(nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var record ReportRecord
+	if err := json.Unmarshal([]byte(lines[2]), &record); err != nil {
+		t.Fatalf("Expected JSON line, got %v", err)
+	}
+	if record.Type != "errors.DatabaseError" {
+		t.Errorf("Expected type errors.DatabaseError, got %s", record.Type)
+	}
+	if record.Fingerprint != Fingerprint(other) {
+		t.Errorf("Expected fingerprint %s, got %s", Fingerprint(other), record.Fingerprint)
+	}
+	if record.Message != other.Error() {
+		t.Errorf("Expected message %q, got %q", other.Error(), record.Message)
+	}
+	if !record.Timestamp.Equal(clock.Now()) {
+		t.Errorf("Expected timestamp %v, got %v", clock.Now(), record.Timestamp)
+	}
+
+	summary := r.Summary()
+	if len(summary) != 2 {
+		t.Errorf("Expected 2 fingerprints, got %v", summary)
+	}
+	if summary[Fingerprint(first)] != 2 {
+		t.Errorf("Expected 2 reports for the validation fingerprint, got %d", summary[Fingerprint(first)])
+	}
+	if summary[Fingerprint(other)] != 1 {
+		t.Errorf("Expected 1 report for the database fingerprint, got %d", summary[Fingerprint(other)])
+	}
+}
+
+func TestReporter_SummaryIsCopy(t *testing.T) {
+	r := NewReporter(&bytes.Buffer{})
+	r.Report("boom")
+
+	r.Summary()[Fingerprint("boom")] = 100
+
+	if r.Summary()[Fingerprint("boom")] != 1 {
+		t.Error("Expected Summary to return a copy")
+	}
+}
@@ -0,0 +1,96 @@
+package gotrycatch
+
+import "fmt"
+
+// ============================================
+// Result - Functional-style alternative to TryBlock
+// ============================================
+
+// Result holds either a successful value of type T or a captured error.
+// It is an immutable value type; all methods return new Results.
+type Result[T any] struct {
+	value T
+	err   interface{}
+}
+
+// Ok returns a successful Result holding v.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err returns a failed Result holding the error e.
+// A nil e is replaced with a descriptive error so the Result is still a failure.
+func Err[T any](e interface{}) Result[T] {
+	if e == nil {
+		e = fmt.Errorf("gotrycatch: Err called with nil error")
+	}
+	return Result[T]{err: e}
+}
+
+// TryToResult executes fn and converts its outcome into a Result.
+// A panic inside fn becomes an Err holding the panic value.
+func TryToResult[T any](fn func() T) Result[T] {
+	tb := TryWithResult(fn)
+	if tb.HasError() {
+		return Err[T](tb.GetError())
+	}
+	return Ok(tb.GetResult())
+}
+
+// IsOk returns true if the Result holds a value.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// IsErr returns true if the Result holds an error.
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+// GetError returns the held error, or nil for a successful Result.
+func (r Result[T]) GetError() interface{} {
+	return r.err
+}
+
+// Map applies fn to the value of a successful Result.
+// A panic inside fn turns the Result into an Err. Failed Results are returned unchanged.
+func (r Result[T]) Map(fn func(T) T) Result[T] {
+	if r.err != nil {
+		return r
+	}
+	return TryToResult(func() T { return fn(r.value) })
+}
+
+// MapResult applies fn to the value of a successful Result, changing its type.
+// Use this instead of Map when the result type differs, since methods cannot have type parameters.
+func MapResult[T any, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return TryToResult(func() U { return fn(r.value) })
+}
+
+// OrElse returns the value if successful, or defaultValue otherwise.
+func (r Result[T]) OrElse(defaultValue T) T {
+	if r.err != nil {
+		return defaultValue
+	}
+	return r.value
+}
+
+// Unwrap returns the value if successful, or re-throws the held error.
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		debugLog("Result.Unwrap: re-throwing error of type %T: %v", r.err, r.err)
+		panic(r.err)
+	}
+	return r.value
+}
+
+// String returns a friendly string representation for debugging.
+func (r Result[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("Err(%T(%v))", r.err, r.err)
+	}
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
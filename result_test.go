@@ -0,0 +1,99 @@
+package gotrycatch
+
+import (
+	"strconv"
+	"testing"
+)
+
+// ============================================
+// Result 测试
+// ============================================
+
+func TestResult_Ok(t *testing.T) {
+	r := Ok(42)
+
+	if !r.IsOk() || r.IsErr() {
+		t.Error("Expected Ok result")
+	}
+	if r.Unwrap() != 42 {
+		t.Errorf("Expected 42, got %v", r.Unwrap())
+	}
+	if r.GetError() != nil {
+		t.Errorf("Expected nil error, got %v", r.GetError())
+	}
+	if r.String() != "Ok(42)" {
+		t.Errorf("Expected 'Ok(42)', got %v", r.String())
+	}
+}
+
+func TestResult_Err(t *testing.T) {
+	r := Err[int]("failed")
+
+	if r.IsOk() || !r.IsErr() {
+		t.Error("Expected Err result")
+	}
+	if r.GetError() != "failed" {
+		t.Errorf("Expected error 'failed', got %v", r.GetError())
+	}
+
+	tb := Try(func() { r.Unwrap() })
+	if tb.GetError() != "failed" {
+		t.Errorf("Expected Unwrap to re-throw 'failed', got %v", tb.GetError())
+	}
+}
+
+func TestResult_ErrNil(t *testing.T) {
+	if !Err[int](nil).IsErr() {
+		t.Error("Expected Err(nil) to still be a failure")
+	}
+}
+
+func TestTryToResult(t *testing.T) {
+	ok := TryToResult(func() string { return "done" })
+	if ok.OrElse("fallback") != "done" {
+		t.Errorf("Expected 'done', got %v", ok.OrElse("fallback"))
+	}
+
+	failed := TryToResult(func() string { panic("boom") })
+	if failed.GetError() != "boom" {
+		t.Errorf("Expected error 'boom', got %v", failed.GetError())
+	}
+	if failed.OrElse("fallback") != "fallback" {
+		t.Errorf("Expected 'fallback', got %v", failed.OrElse("fallback"))
+	}
+}
+
+func TestResult_MapChaining(t *testing.T) {
+	r := Ok(2).
+		Map(func(v int) int { return v * 10 }).
+		Map(func(v int) int { return v + 1 })
+	if r.Unwrap() != 21 {
+		t.Errorf("Expected 21, got %v", r.Unwrap())
+	}
+
+	var called bool
+	failed := Ok(2).
+		Map(func(v int) int { panic("map failed") }).
+		Map(func(v int) int { called = true; return v })
+	if called {
+		t.Error("Expected Map after failure not to be called")
+	}
+	if failed.GetError() != "map failed" {
+		t.Errorf("Expected error 'map failed', got %v", failed.GetError())
+	}
+}
+
+func TestMapResult(t *testing.T) {
+	r := MapResult(Ok(7), strconv.Itoa)
+	if r.Unwrap() != "7" {
+		t.Errorf("Expected '7', got %v", r.Unwrap())
+	}
+
+	failed := MapResult(Err[int]("bad"), strconv.Itoa)
+	if failed.GetError() != "bad" {
+		t.Errorf("Expected error to propagate, got %v", failed.GetError())
+	}
+	if failed.OrElse("none") != "none" {
+		t.Errorf("Expected 'none', got %v", failed.OrElse("none"))
+	}
+}
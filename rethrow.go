@@ -0,0 +1,61 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================
+// WrapRethrows - Keeping context across Finally layers
+// ============================================
+
+// WrapRethrows makes Finally and SafeFinally re-throw unhandled errors wrapped in a
+// *RethrowError that records where they were re-thrown and the original stack, so an
+// error bubbling through several Finally layers keeps the full story. Each layer adds
+// its own wrapper, unless FlattenRethrows is on, in which case the first wrapper is
+// re-thrown unchanged by the outer layers. CatchChain sees through the wrappers and matches the original value;
+// plain Catch matches only *RethrowError. UnhandledPolicy receives the wrapper too.
+// It is off by default.
+var WrapRethrows = false
+
+// RethrowError wraps a value re-thrown by Finally when WrapRethrows is on.
+type RethrowError struct {
+	Value  interface{} // The re-thrown value, possibly another *RethrowError
+	Site   string      // Where Finally re-threw it, as file:line
+	Origin []string    // Stack of the original panic: the panic stack when CaptureStack was on, else the error's own stack
+}
+
+func (e *RethrowError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (rethrown at %s)", Describe(e.Value), e.Site)
+	if len(e.Origin) > 0 {
+		b.WriteString("\noriginal stack:\n\t")
+		b.WriteString(strings.Join(e.Origin, "\n\t"))
+	}
+	return b.String()
+}
+
+// Unwrap returns the wrapped value if it is an error, so errors.Is and errors.As see
+// through the wrapper. Use CatchChain to match wrapped values of any type.
+func (e *RethrowError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// wrapRethrow wraps err in a *RethrowError when WrapRethrows is on. origin is the
+// captured panic stack, if any. With FlattenRethrows, an err that is already a
+// *RethrowError is returned as is.
+func wrapRethrow(err interface{}, origin []string) interface{} {
+	if !WrapRethrows {
+		return err
+	}
+	if _, wrapped := err.(*RethrowError); wrapped && FlattenRethrows {
+		return err
+	}
+	if len(origin) == 0 {
+		origin = stackOf(err)
+	}
+	return &RethrowError{Value: err, Site: throwCaller(), Origin: origin}
+}
@@ -0,0 +1,115 @@
+package gotrycatch
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// WrapRethrows 测试
+// ============================================
+
+func enableWrapRethrows(t *testing.T) {
+	t.Helper()
+	old := WrapRethrows
+	WrapRethrows = true
+	t.Cleanup(func() { WrapRethrows = old })
+}
+
+func TestWrapRethrows_TwoLayers(t *testing.T) {
+	enableWrapRethrows(t)
+
+	_, _, line, _ := runtime.Caller(0)
+	tb := Try(func() {
+		Try(func() {
+			Try(func() {
+				panic(trycatcherrors.NewValidationError("email", "invalid", 1001))
+			}).Finally(func() {})
+		}).Finally(func() {})
+	})
+
+	outer, ok := tb.GetError().(*RethrowError)
+	if !ok {
+		t.Fatalf("Expected *RethrowError, got %T", tb.GetError())
+	}
+	inner, ok := outer.Value.(*RethrowError)
+	if !ok {
+		t.Fatalf("Expected nested *RethrowError, got %T", outer.Value)
+	}
+	if want := fmt.Sprintf("rethrow_test.go:%d", line+6); outer.Site != want {
+		t.Errorf("Expected outer site %s, got %s", want, outer.Site)
+	}
+	if want := fmt.Sprintf("rethrow_test.go:%d", line+5); inner.Site != want {
+		t.Errorf("Expected inner site %s, got %s", want, inner.Site)
+	}
+	if len(inner.Origin) == 0 {
+		t.Error("Expected original stack from the error to be kept")
+	}
+
+	var caught trycatcherrors.ValidationError
+	CatchChain[trycatcherrors.ValidationError](tb, func(e trycatcherrors.ValidationError) { caught = e })
+	if caught.Field != "email" {
+		t.Errorf("Expected CatchChain to match the original ValidationError, got %v", tb.GetError())
+	}
+
+	var ve trycatcherrors.ValidationError
+	if !errors.As(outer, &ve) {
+		t.Error("Expected errors.As to see through the wrappers")
+	}
+}
+
+func TestWrapRethrows_FlattenKeepsFirstWrapper(t *testing.T) {
+	enableWrapRethrows(t)
+	FlattenRethrows = true
+	defer func() { FlattenRethrows = false }()
+
+	_, _, line, _ := runtime.Caller(0)
+	tb := Try(func() {
+		Try(func() {
+			Try(func() { panic("deep") }).Finally(func() {})
+		}).Finally(func() {})
+	})
+
+	rw, ok := tb.GetError().(*RethrowError)
+	if !ok {
+		t.Fatalf("Expected *RethrowError, got %T", tb.GetError())
+	}
+	if rw.Value != "deep" {
+		t.Errorf("Expected a single wrapper around the original value, got %T", rw.Value)
+	}
+	if want := fmt.Sprintf("rethrow_test.go:%d", line+3); rw.Site != want {
+		t.Errorf("Expected the innermost re-throw site %s, got %s", want, rw.Site)
+	}
+}
+
+func TestWrapRethrows_NonErrorValue(t *testing.T) {
+	enableWrapRethrows(t)
+
+	tb := Try(func() {
+		Try(func() { panic("plain") }).Finally(func() {})
+	})
+
+	var caught string
+	CatchChain[string](tb, func(s string) { caught = s })
+	if caught != "plain" {
+		t.Errorf("Expected CatchChain to reach the wrapped string, got %v", tb.GetError())
+	}
+	if msg := tb.GetError().(*RethrowError).Error(); !strings.HasPrefix(msg, "plain (rethrown at rethrow_test.go:") {
+		t.Errorf("Expected message with value and site, got %q", msg)
+	}
+}
+
+func TestWrapRethrows_Disabled(t *testing.T) {
+	tb := Try(func() {
+		Try(func() { panic("plain") }).Finally(func() {})
+	})
+
+	if tb.GetError() != "plain" {
+		t.Errorf("Expected unwrapped value when disabled, got %T", tb.GetError())
+	}
+}
@@ -0,0 +1,158 @@
+package gotrycatch
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// ============================================
+// Strategy - Pluggable retry backoff
+// ============================================
+
+// Strategy decides how long to wait before the next retry.
+// NextDelay is called after attempt (1-based) failed with lastErr, and returns the
+// delay before the next attempt. Returning stop=true ends retrying and the last
+// failure is reported to the caller.
+type Strategy interface {
+	NextDelay(attempt int, lastErr interface{}) (delay time.Duration, stop bool)
+}
+
+// sleep is a variable so tests can observe delays without waiting.
+var sleep = time.Sleep
+
+// FixedDelay waits the same Delay between attempts and stops after MaxAttempts
+// attempts in total. A MaxAttempts below 2 means no retries.
+type FixedDelay struct {
+	Delay       time.Duration
+	MaxAttempts int
+}
+
+// NextDelay implements Strategy.
+func (s FixedDelay) NextDelay(attempt int, lastErr interface{}) (time.Duration, bool) {
+	if attempt >= s.MaxAttempts {
+		return 0, true
+	}
+	return s.Delay, false
+}
+
+// ExponentialBackoff multiplies the delay by Multiplier after every attempt,
+// starting at Initial and capped at Max when Max is positive. A Multiplier of 1 or
+// less is treated as 2. It stops after MaxAttempts attempts in total.
+type ExponentialBackoff struct {
+	Initial     time.Duration
+	Max         time.Duration
+	Multiplier  float64
+	MaxAttempts int
+}
+
+// NextDelay implements Strategy.
+func (s ExponentialBackoff) NextDelay(attempt int, lastErr interface{}) (time.Duration, bool) {
+	if attempt >= s.MaxAttempts {
+		return 0, true
+	}
+	return s.delay(attempt), false
+}
+
+func (s ExponentialBackoff) delay(attempt int) time.Duration {
+	multiplier := s.Multiplier
+	if multiplier <= 1 {
+		multiplier = 2
+	}
+	d := float64(s.Initial) * math.Pow(multiplier, float64(attempt-1))
+	if s.Max > 0 && d > float64(s.Max) {
+		return s.Max
+	}
+	if d > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(d)
+}
+
+// ExponentialJitter is ExponentialBackoff with "full jitter": each delay is chosen
+// uniformly between zero and the exponential delay, which spreads out retries from
+// many clients failing at the same time.
+type ExponentialJitter struct {
+	ExponentialBackoff
+
+	rand func() float64 // injectable random source for tests; defaults to math/rand/v2
+}
+
+// NextDelay implements Strategy.
+func (s ExponentialJitter) NextDelay(attempt int, lastErr interface{}) (time.Duration, bool) {
+	if attempt >= s.MaxAttempts {
+		return 0, true
+	}
+	random := s.rand
+	if random == nil {
+		random = rand.Float64
+	}
+	return time.Duration(random() * float64(s.delay(attempt))), false
+}
+
+// ============================================
+// Retry - Re-running panicking functions
+// ============================================
+
+// Retry runs fn under Try until it completes without panicking or strategy says to stop,
+// sleeping for the strategy's delay between attempts. The returned TryBlock holds the
+// last panic, or no error if an attempt succeeded. A nil strategy runs fn once.
+func Retry(strategy Strategy, fn func()) *TryBlock {
+	return RetryDetailed(strategy, fn).Block
+}
+
+// RetryResult describes the outcome of RetryDetailed.
+type RetryResult struct {
+	Attempts   int           // Number of times fn was run
+	TotalDelay time.Duration // Sum of the delays slept between attempts
+	Succeeded  bool          // An attempt completed without panicking; false means retries were exhausted
+	Block      *TryBlock     // The block of the last attempt
+}
+
+// RetryDetailed behaves like Retry and also reports how many attempts were made,
+// how long was spent waiting between them, and whether the call finally succeeded.
+func RetryDetailed(strategy Strategy, fn func()) RetryResult {
+	var result RetryResult
+	for {
+		result.Attempts++
+		result.Block = Try(fn)
+		if !result.Block.HasError() {
+			result.Succeeded = true
+			return result
+		}
+		if strategy == nil {
+			return result
+		}
+
+		delay, stop := strategy.NextDelay(result.Attempts, result.Block.err)
+		if stop {
+			debugLog("Retry: giving up after %d attempt(s), last error %T", result.Attempts, result.Block.err)
+			return result
+		}
+		debugLog("Retry: attempt %d failed with %T, retrying in %v", result.Attempts, result.Block.err, delay)
+		sleep(delay)
+		result.TotalDelay += delay
+	}
+}
+
+// RetryWithResult is like Retry for functions that return a value.
+// The result of the first successful attempt is available through GetResult.
+func RetryWithResult[T any](strategy Strategy, fn func() T) *TryBlockWithResult[T] {
+	for attempt := 1; ; attempt++ {
+		tb := TryWithResult(fn)
+		if !tb.HasError() {
+			return tb
+		}
+		if strategy == nil {
+			return tb
+		}
+
+		delay, stop := strategy.NextDelay(attempt, tb.err)
+		if stop {
+			debugLog("RetryWithResult: giving up after %d attempt(s), last error %T", attempt, tb.err)
+			return tb
+		}
+		debugLog("RetryWithResult: attempt %d failed with %T, retrying in %v", attempt, tb.err, delay)
+		sleep(delay)
+	}
+}
@@ -0,0 +1,256 @@
+package gotrycatch
+
+import (
+	"testing"
+	"time"
+)
+
+// interceptSleep replaces sleep for the duration of the test and records requested delays.
+func interceptSleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var delays []time.Duration
+	original := sleep
+	sleep = func(d time.Duration) { delays = append(delays, d) }
+	t.Cleanup(func() { sleep = original })
+	return &delays
+}
+
+// delaySequence calls NextDelay for attempts 1..n and returns the delays until stop.
+func delaySequence(s Strategy, n int) []time.Duration {
+	var delays []time.Duration
+	for attempt := 1; attempt <= n; attempt++ {
+		d, stop := s.NextDelay(attempt, "err")
+		if stop {
+			break
+		}
+		delays = append(delays, d)
+	}
+	return delays
+}
+
+func equalDelays(a, b []time.Duration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ============================================
+// Strategy 测试
+// ============================================
+
+func TestFixedDelay_Sequence(t *testing.T) {
+	got := delaySequence(FixedDelay{Delay: 10 * time.Millisecond, MaxAttempts: 4}, 10)
+	want := []time.Duration{10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond}
+
+	if !equalDelays(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestFixedDelay_ZeroValueNeverRetries(t *testing.T) {
+	if _, stop := (FixedDelay{}).NextDelay(1, "err"); !stop {
+		t.Error("Expected zero FixedDelay to stop after the first attempt")
+	}
+}
+
+func TestExponentialBackoff_Sequence(t *testing.T) {
+	s := ExponentialBackoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2, MaxAttempts: 6}
+	got := delaySequence(s, 10)
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second, // capped
+	}
+
+	if !equalDelays(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestExponentialBackoff_DefaultMultiplier(t *testing.T) {
+	s := ExponentialBackoff{Initial: time.Millisecond, MaxAttempts: 4}
+	got := delaySequence(s, 10)
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond}
+
+	if !equalDelays(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestExponentialJitter_Sequence(t *testing.T) {
+	s := ExponentialJitter{
+		ExponentialBackoff: ExponentialBackoff{Initial: 100 * time.Millisecond, Multiplier: 2, MaxAttempts: 4},
+		rand:               func() float64 { return 0.5 },
+	}
+	got := delaySequence(s, 10)
+	want := []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond}
+
+	if !equalDelays(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestExponentialJitter_Bounds(t *testing.T) {
+	s := ExponentialJitter{ExponentialBackoff: ExponentialBackoff{Initial: 100 * time.Millisecond, MaxAttempts: 100}}
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		upper := s.delay(attempt)
+		d, stop := s.NextDelay(attempt, "err")
+		if stop {
+			t.Fatalf("Expected attempt %d not to stop", attempt)
+		}
+		if d < 0 || d > upper {
+			t.Errorf("Expected delay for attempt %d in [0, %v], got %v", attempt, upper, d)
+		}
+	}
+}
+
+// ============================================
+// Retry 测试
+// ============================================
+
+func TestRetry_SucceedsAfterFailures(t *testing.T) {
+	delays := interceptSleep(t)
+	var calls int
+
+	tb := Retry(FixedDelay{Delay: time.Millisecond, MaxAttempts: 5}, func() {
+		calls++
+		if calls < 3 {
+			panic("flaky")
+		}
+	})
+
+	if tb.HasError() {
+		t.Errorf("Expected success, got %v", tb.GetError())
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+	if len(*delays) != 2 {
+		t.Errorf("Expected 2 sleeps, got %v", *delays)
+	}
+}
+
+func TestRetry_Exhausted(t *testing.T) {
+	interceptSleep(t)
+	var calls int
+
+	tb := Retry(FixedDelay{MaxAttempts: 3}, func() {
+		calls++
+		panic(calls)
+	})
+
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+	if tb.GetError() != 3 {
+		t.Errorf("Expected last error 3, got %v", tb.GetError())
+	}
+}
+
+func TestRetry_StrategySeesLastError(t *testing.T) {
+	interceptSleep(t)
+	var seen []interface{}
+
+	Retry(strategyFunc(func(attempt int, lastErr interface{}) (time.Duration, bool) {
+		seen = append(seen, lastErr)
+		return 0, lastErr == "fatal"
+	}), func() {
+		if len(seen) == 0 {
+			panic("transient")
+		}
+		panic("fatal")
+	})
+
+	if len(seen) != 2 || seen[0] != "transient" || seen[1] != "fatal" {
+		t.Errorf("Expected [transient fatal], got %v", seen)
+	}
+}
+
+func TestRetry_NilStrategy(t *testing.T) {
+	var calls int
+	tb := Retry(nil, func() {
+		calls++
+		panic("boom")
+	})
+
+	if calls != 1 || tb.GetError() != "boom" {
+		t.Errorf("Expected a single failing call, got %d calls and %v", calls, tb.GetError())
+	}
+}
+
+func TestRetryWithResult(t *testing.T) {
+	interceptSleep(t)
+	var calls int
+
+	tb := RetryWithResult(ExponentialBackoff{Initial: time.Millisecond, MaxAttempts: 3}, func() int {
+		calls++
+		if calls == 1 {
+			panic("flaky")
+		}
+		return 42
+	})
+
+	if tb.HasError() || tb.GetResult() != 42 {
+		t.Errorf("Expected result 42 without error, got %v (%v)", tb.GetResult(), tb.GetError())
+	}
+}
+
+// strategyFunc adapts a function to the Strategy interface.
+type strategyFunc func(attempt int, lastErr interface{}) (time.Duration, bool)
+
+func (f strategyFunc) NextDelay(attempt int, lastErr interface{}) (time.Duration, bool) {
+	return f(attempt, lastErr)
+}
+
+// ============================================
+// RetryDetailed 测试
+// ============================================
+
+func TestRetryDetailed_FailTwiceThenSucceed(t *testing.T) {
+	interceptSleep(t)
+	var calls int
+
+	result := RetryDetailed(FixedDelay{Delay: 10 * time.Millisecond, MaxAttempts: 5}, func() {
+		calls++
+		if calls <= 2 {
+			panic("flaky")
+		}
+	})
+
+	if result.Attempts != 3 || !result.Succeeded {
+		t.Errorf("Expected 3 attempts and success, got %+v", result)
+	}
+	if result.TotalDelay != 20*time.Millisecond {
+		t.Errorf("Expected total delay 20ms, got %v", result.TotalDelay)
+	}
+	if result.Block.HasError() {
+		t.Errorf("Expected clean final block, got %v", result.Block.GetError())
+	}
+}
+
+func TestRetryDetailed_Exhausted(t *testing.T) {
+	interceptSleep(t)
+
+	result := RetryDetailed(ExponentialBackoff{Initial: time.Millisecond, MaxAttempts: 3}, func() {
+		panic("down")
+	})
+
+	if result.Attempts != 3 || result.Succeeded {
+		t.Errorf("Expected 3 attempts and exhaustion, got %+v", result)
+	}
+	if result.TotalDelay != 3*time.Millisecond {
+		t.Errorf("Expected total delay 1ms+2ms, got %v", result.TotalDelay)
+	}
+	if result.Block.GetError() != "down" {
+		t.Errorf("Expected last error 'down', got %v", result.Block.GetError())
+	}
+}
@@ -0,0 +1,83 @@
+package gotrycatch
+
+// ============================================
+// TryScope - Ordered cleanups and resources
+// ============================================
+
+// Scope collects the cleanups registered inside a TryScope. Defer and Acquire push
+// onto the same stack, so a scope that mixes them unwinds in one combined order.
+type Scope struct {
+	cleanups []func()
+}
+
+// TryScope runs fn like Try and then unwinds the scope: every cleanup registered with
+// Defer or Acquire runs in reverse registration order across both mechanisms, whether
+// fn returned or panicked, and before any Catch handler sees the TryBlock.
+//
+//	tb := gotrycatch.TryScope(func(s *gotrycatch.Scope) {
+//		f := gotrycatch.Acquire(s, openFile, closeFile) // released second
+//		s.Defer(flushMetrics)                           // runs first
+//		process(f)
+//	})
+//
+// All cleanups run even if one panics. As with TryWithFinally, cleanup panics do not
+// replace fn's panic: when more than one value panics, the block holds them all as a
+// MultiError, fn's first and then the cleanups' in the order they ran.
+func TryScope(fn func(s *Scope)) *TryBlock {
+	s := &Scope{}
+	return Try(func() {
+		defer func() {
+			panics := s.unwind()
+			if len(panics) == 0 {
+				return
+			}
+			if fnErr := normalizePanic(recover(), true); fnErr != nil {
+				panics = append([]interface{}{fnErr}, panics...)
+			}
+			if len(panics) == 1 {
+				panic(panics[0])
+			}
+			debugLog("TryScope: merging %d panics", len(panics))
+			panic(mergeRethrow(panics...))
+		}()
+		fn(s)
+	})
+}
+
+// Defer registers cleanup to run when the scope unwinds. A nil cleanup is ignored.
+// Defer must only be called from fn's goroutine while fn is running.
+func (s *Scope) Defer(cleanup func()) {
+	if cleanup == nil {
+		debugLog("Scope.Defer: cleanup is nil, ignoring")
+		return
+	}
+	s.cleanups = append(s.cleanups, cleanup)
+}
+
+// Acquire calls acquire and registers release for the returned resource on the
+// scope's cleanup stack, so it is released in order with Defer registrations. If
+// acquire panics, nothing is registered. A nil release only acquires.
+func Acquire[R any](s *Scope, acquire func() R, release func(R)) R {
+	resource := acquire()
+	if release != nil {
+		s.Defer(func() { release(resource) })
+	}
+	return resource
+}
+
+// unwind runs the registered cleanups last-first, like the deferred calls they stand
+// in for, and returns the values any of them panicked with. A panicking cleanup does
+// not stop the rest from running.
+func (s *Scope) unwind() []interface{} {
+	cleanups := s.cleanups
+	s.cleanups = nil
+	debugLog("TryScope: unwinding %d cleanup(s)", len(cleanups))
+
+	var panics []interface{}
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		if err := recoverFrom(cleanups[i]); err != nil {
+			panics = append(panics, err)
+		}
+	}
+	return panics
+}
@@ -0,0 +1,104 @@
+package gotrycatch
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ============================================
+// TryScope 测试
+// ============================================
+
+func TestTryScope_CombinedLIFOOrder(t *testing.T) {
+	var order []string
+	acquire := func(name string) func() string {
+		return func() string {
+			order = append(order, "acquire "+name)
+			return name
+		}
+	}
+	release := func(name string) { order = append(order, "release "+name) }
+
+	tb := TryScope(func(s *Scope) {
+		Acquire(s, acquire("db"), release)
+		s.Defer(func() { order = append(order, "defer 1") })
+		Acquire(s, acquire("file"), release)
+		s.Defer(func() { order = append(order, "defer 2") })
+		panic("work failed")
+	})
+
+	want := []string{
+		"acquire db", "acquire file",
+		"defer 2", "release file", "defer 1", "release db",
+	}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("Expected order %v, got %v", want, order)
+	}
+	if tb.GetError() != "work failed" {
+		t.Errorf("Expected captured panic, got %v", tb.GetError())
+	}
+}
+
+func TestTryScope_CleanupPanicRunsRemaining(t *testing.T) {
+	var order []string
+
+	tb := TryScope(func(s *Scope) {
+		s.Defer(func() { order = append(order, "first") })
+		s.Defer(func() { panic("cleanup failed") })
+		s.Defer(func() { order = append(order, "last") })
+	})
+
+	if !reflect.DeepEqual(order, []string{"last", "first"}) {
+		t.Errorf("Expected remaining cleanups to run, got %v", order)
+	}
+	if tb.GetError() != "cleanup failed" {
+		t.Errorf("Expected cleanup panic to be captured, got %v", tb.GetError())
+	}
+}
+
+func TestTryScope_FnAndCleanupPanicsKept(t *testing.T) {
+	tb := TryScope(func(s *Scope) {
+		s.Defer(func() { panic("close failed") })
+		s.Defer(func() { panic("flush failed") })
+		panic("query failed")
+	})
+
+	multi, ok := tb.GetError().(MultiError)
+	if !ok {
+		t.Fatalf("Expected MultiError, got %T: %v", tb.GetError(), tb.GetError())
+	}
+	want := []interface{}{"query failed", "flush failed", "close failed"}
+	if !reflect.DeepEqual(multi.Errors, want) {
+		t.Errorf("Expected %v, got %v", want, multi.Errors)
+	}
+}
+
+func TestTryScope_AcquirePanicRegistersNothing(t *testing.T) {
+	released := false
+
+	tb := TryScope(func(s *Scope) {
+		Acquire(s, func() int { panic("connect failed") }, func(int) { released = true })
+	})
+
+	if released {
+		t.Error("Expected release not to run for a failed acquire")
+	}
+	if tb.GetError() != "connect failed" {
+		t.Errorf("Expected acquire panic, got %v", tb.GetError())
+	}
+}
+
+func TestTryScope_NoPanic(t *testing.T) {
+	var released int
+
+	tb := TryScope(func(s *Scope) {
+		if got := Acquire(s, func() int { return 7 }, func(v int) { released = v }); got != 7 {
+			t.Errorf("Expected acquired resource 7, got %d", got)
+		}
+		s.Defer(nil)
+	})
+
+	if tb.HasError() || released != 7 {
+		t.Errorf("Expected clean block with resource released, got %v and %d", tb, released)
+	}
+}
@@ -0,0 +1,115 @@
+package gotrycatch
+
+import (
+	"runtime"
+	"sort"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Severity - Error severity classification
+// ============================================
+
+// Severity classifies how serious a captured error is.
+// Higher values are more severe.
+type Severity int
+
+const (
+	// SeverityUnknown is the zero value. As a threshold it means "disabled".
+	SeverityUnknown Severity = iota
+	// SeverityInfo marks errors that are expected and purely informational.
+	SeverityInfo
+	// SeverityWarning marks errors caused by bad input or business rules.
+	SeverityWarning
+	// SeverityError marks ordinary operational failures.
+	SeverityError
+	// SeverityCritical marks failures that should never be silently swallowed.
+	SeverityCritical
+)
+
+// String returns the lower-case name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// SeverityProvider can be implemented by custom error types to report their own severity.
+type SeverityProvider interface {
+	Severity() Severity
+}
+
+// SeverityOf returns the severity of a captured error value.
+// Values implementing SeverityProvider report their own severity; built-in error types
+// use a fixed mapping; runtime errors are Critical; everything else is SeverityError.
+// Returns SeverityUnknown for nil.
+func SeverityOf(err interface{}) Severity {
+	switch e := err.(type) {
+	case nil:
+		return SeverityUnknown
+	case SeverityProvider:
+		return e.Severity()
+	case trycatcherrors.ValidationError, trycatcherrors.ValidationErrors, *trycatcherrors.ValidationTree, trycatcherrors.BusinessLogicError, trycatcherrors.RateLimitError:
+		return SeverityWarning
+	case trycatcherrors.AuthError, trycatcherrors.NetworkError:
+		return SeverityError
+	case trycatcherrors.DatabaseError, trycatcherrors.BatchDatabaseError, trycatcherrors.ConfigError:
+		return SeverityCritical
+	case runtime.Error:
+		return SeverityCritical
+	default:
+		return SeverityError
+	}
+}
+
+// RethrowAtOrAbove makes Finally re-throw errors at or above this severity even if a
+// Catch or CatchAny handler already handled them, so critical failures always crash.
+// Handlers still run first, which allows logging before the re-throw.
+// The zero value (SeverityUnknown) disables the behavior.
+var RethrowAtOrAbove Severity
+
+// shouldRethrow reports whether Finally must re-throw the given error.
+func shouldRethrow(err interface{}, handled bool) bool {
+	if err == nil {
+		return false
+	}
+	if !handled {
+		return true
+	}
+	if RethrowAtOrAbove != SeverityUnknown && SeverityOf(err) >= RethrowAtOrAbove {
+		debugLog("Finally: severity %v of %T is at or above threshold %v", SeverityOf(err), err, RethrowAtOrAbove)
+		return true
+	}
+	return false
+}
+
+// SortBySeverity sorts errs in place so the most severe errors come first.
+// The sort is stable: errors of equal severity keep their original order.
+func SortBySeverity(errs []interface{}) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return SeverityOf(errs[i]) > SeverityOf(errs[j])
+	})
+}
+
+// GroupBySeverity partitions errs by SeverityOf, for summarizing failures on dashboards.
+// Values of unknown types fall under SeverityError like in SeverityOf, and nil values
+// under SeverityUnknown. Each group keeps the original order; severities with no errors
+// are absent from the map.
+func GroupBySeverity(errs []interface{}) map[Severity][]interface{} {
+	groups := make(map[Severity][]interface{})
+	for _, err := range errs {
+		s := SeverityOf(err)
+		groups[s] = append(groups[s], err)
+	}
+	return groups
+}
@@ -0,0 +1,187 @@
+package gotrycatch
+
+import (
+	"errors"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Severity 测试
+// ============================================
+
+type customSeverityError struct{}
+
+func (customSeverityError) Severity() Severity { return SeverityInfo }
+
+func TestSeverityOf(t *testing.T) {
+	var nilMap map[string]int
+	runtimeErr := Try(func() { nilMap["x"] = 1 }).GetError()
+
+	tests := []struct {
+		name string
+		err  interface{}
+		want Severity
+	}{
+		{"nil", nil, SeverityUnknown},
+		{"ValidationError", trycatcherrors.NewValidationError("f", "m", 1), SeverityWarning},
+		{"ValidationTree", trycatcherrors.NewValidationTree(), SeverityWarning},
+		{"BusinessLogicError", trycatcherrors.NewBusinessLogicError("r", "d"), SeverityWarning},
+		{"RateLimitError", trycatcherrors.NewRateLimitError("api", 10, 11, 5), SeverityWarning},
+		{"AuthError", trycatcherrors.NewAuthError("login", "u", "bad"), SeverityError},
+		{"NetworkError", trycatcherrors.NewNetworkError("http://x", 503), SeverityError},
+		{"DatabaseError", trycatcherrors.NewDatabaseError("INSERT", "t", nil), SeverityCritical},
+		{"ConfigError", trycatcherrors.NewConfigError("k", "v", "r"), SeverityCritical},
+		{"runtime.Error", runtimeErr, SeverityCritical},
+		{"provider", customSeverityError{}, SeverityInfo},
+		{"string", "oops", SeverityError},
+		{"error", errors.New("plain"), SeverityError},
+	}
+
+	for _, tt := range tests {
+		if got := SeverityOf(tt.err); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestSeverity_String(t *testing.T) {
+	if SeverityCritical.String() != "critical" {
+		t.Errorf("Expected 'critical', got %v", SeverityCritical.String())
+	}
+	if Severity(99).String() != "unknown" {
+		t.Errorf("Expected 'unknown', got %v", Severity(99).String())
+	}
+}
+
+func TestRethrowAtOrAbove_CriticalStillRethrows(t *testing.T) {
+	defer func() { RethrowAtOrAbove = SeverityUnknown }()
+	RethrowAtOrAbove = SeverityCritical
+
+	var anyCalled, finallyCalled bool
+	rethrown := Try(func() {
+		tb := Try(func() {
+			panic(trycatcherrors.NewDatabaseError("DELETE", "users", nil))
+		})
+		tb.CatchAny(func(err interface{}) {
+			anyCalled = true
+		}).Finally(func() {
+			finallyCalled = true
+		})
+	})
+
+	if !anyCalled {
+		t.Error("Expected CatchAny handler to still run")
+	}
+	if !finallyCalled {
+		t.Error("Expected Finally function to run")
+	}
+	if _, ok := rethrown.GetError().(trycatcherrors.DatabaseError); !ok {
+		t.Errorf("Expected DatabaseError to be re-thrown, got %T", rethrown.GetError())
+	}
+}
+
+func TestRethrowAtOrAbove_BelowThresholdSwallowed(t *testing.T) {
+	defer func() { RethrowAtOrAbove = SeverityUnknown }()
+	RethrowAtOrAbove = SeverityCritical
+
+	outer := Try(func() {
+		Try(func() {
+			panic(trycatcherrors.NewValidationError("f", "m", 1))
+		}).CatchAny(func(err interface{}) {}).Finally(func() {})
+	})
+
+	if outer.HasError() {
+		t.Errorf("Expected Warning severity to be swallowed, got %v", outer.GetError())
+	}
+}
+
+func TestRethrowAtOrAbove_Disabled(t *testing.T) {
+	outer := Try(func() {
+		Try(func() {
+			panic(trycatcherrors.NewDatabaseError("DELETE", "users", nil))
+		}).CatchAny(func(err interface{}) {}).Finally(func() {})
+	})
+
+	if outer.HasError() {
+		t.Errorf("Expected no re-throw with threshold disabled, got %v", outer.GetError())
+	}
+}
+
+func TestRethrowAtOrAbove_TryWithResult(t *testing.T) {
+	defer func() { RethrowAtOrAbove = SeverityUnknown }()
+	RethrowAtOrAbove = SeverityError
+
+	outer := Try(func() {
+		tb := TryWithResult(func() int {
+			panic(trycatcherrors.NewNetworkTimeoutError("http://x"))
+		})
+		CatchAnyWithResult(tb, func(err interface{}) {}).Finally(func() {})
+	})
+
+	if _, ok := outer.GetError().(trycatcherrors.NetworkError); !ok {
+		t.Errorf("Expected NetworkError to be re-thrown, got %T", outer.GetError())
+	}
+}
+
+func TestSortBySeverity(t *testing.T) {
+	warning1 := trycatcherrors.NewValidationError("a", "m", 1)
+	critical := trycatcherrors.NewDatabaseError("INSERT", "t", nil)
+	plain := "plain"
+	warning2 := trycatcherrors.NewBusinessLogicError("rule", "d")
+	info := customSeverityError{}
+
+	errs := []interface{}{warning1, info, critical, plain, warning2}
+	SortBySeverity(errs)
+
+	expected := []Severity{SeverityCritical, SeverityError, SeverityWarning, SeverityWarning, SeverityInfo}
+	for i, want := range expected {
+		if got := SeverityOf(errs[i]); got != want {
+			t.Errorf("Position %d: expected %v, got %v", i, want, got)
+		}
+	}
+
+	// Stable for equal severities
+	if v, ok := errs[2].(trycatcherrors.ValidationError); !ok || v.Field != "a" {
+		t.Errorf("Expected ValidationError to keep its place before BusinessLogicError, got %T", errs[2])
+	}
+	if _, ok := errs[3].(trycatcherrors.BusinessLogicError); !ok {
+		t.Errorf("Expected BusinessLogicError at position 3, got %T", errs[3])
+	}
+}
+
+func TestSortBySeverity_Empty(t *testing.T) {
+	SortBySeverity(nil)
+	SortBySeverity([]interface{}{})
+}
+
+func TestGroupBySeverity(t *testing.T) {
+	warning1 := trycatcherrors.NewValidationError("a", "m", 1)
+	critical := trycatcherrors.NewConfigError("db.url", "", "missing")
+	warning2 := trycatcherrors.NewRateLimitError("api", 10, 11, 1)
+	info := customSeverityError{}
+
+	groups := GroupBySeverity([]interface{}{warning1, "plain", critical, 42, warning2, info})
+
+	if len(groups) != 4 {
+		t.Errorf("Expected 4 severity groups, got %d: %v", len(groups), groups)
+	}
+	if w := groups[SeverityWarning]; len(w) != 2 {
+		t.Errorf("Expected two warnings, got %v", w)
+	} else if _, ok := w[1].(trycatcherrors.RateLimitError); !ok {
+		t.Errorf("Expected warnings in original order, got %T second", w[1])
+	}
+	if e := groups[SeverityError]; len(e) != 2 || e[0] != "plain" || e[1] != 42 {
+		t.Errorf("Expected unknown types under SeverityError, got %v", e)
+	}
+	if len(groups[SeverityCritical]) != 1 || len(groups[SeverityInfo]) != 1 {
+		t.Errorf("Expected one critical and one info error, got %v", groups)
+	}
+}
+
+func TestGroupBySeverity_Empty(t *testing.T) {
+	if groups := GroupBySeverity(nil); len(groups) != 0 {
+		t.Errorf("Expected no groups, got %v", groups)
+	}
+}
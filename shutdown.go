@@ -0,0 +1,89 @@
+package gotrycatch
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// ============================================
+// Shutdown - Panic-safe cleanup on process exit
+// ============================================
+
+var (
+	shutdownMu    sync.Mutex
+	shutdownHooks []func()
+)
+
+// OnShutdown registers fn to run when Shutdown is called, or when a signal arrives
+// after HandleSignals. A nil fn is ignored.
+func OnShutdown(fn func()) {
+	if fn == nil {
+		debugLog("OnShutdown: fn is nil, ignoring")
+		return
+	}
+
+	shutdownMu.Lock()
+	defer shutdownMu.Unlock()
+	shutdownHooks = append(shutdownHooks, fn)
+}
+
+// Shutdown runs the registered cleanups in reverse registration order, like deferred
+// calls, and clears them so a second Shutdown is a no-op. Each cleanup runs under its
+// own recover, so a panicking cleanup does not skip the others.
+// The returned TryBlock is clean if nothing panicked; otherwise it holds a MultiError
+// of the cleanup panics, in the order they occurred.
+func Shutdown() *TryBlock {
+	shutdownMu.Lock()
+	hooks := shutdownHooks
+	shutdownHooks = nil
+	shutdownMu.Unlock()
+
+	var errs []interface{}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := recoverFrom(hooks[i]); err != nil {
+			debugLog("Shutdown: cleanup panicked with %T", err)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return &TryBlock{}
+	}
+	return &TryBlock{err: MultiError{Errors: errs}}
+}
+
+// HandleSignals opts in to running Shutdown when the process receives SIGINT or
+// SIGTERM. After the cleanups have run, the process exits with status 1, after
+// reporting any cleanup panics to standard error.
+// The returned function stops listening for the signals.
+func HandleSignals() (stop func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+
+	done := make(chan struct{})
+	go handleShutdownSignal(ch, done)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
+
+// handleShutdownSignal waits for a signal on ch, then runs Shutdown and exits.
+// It returns without doing anything if done is closed first.
+func handleShutdownSignal(ch <-chan os.Signal, done <-chan struct{}) {
+	select {
+	case sig := <-ch:
+		debugLog("HandleSignals: received %v, shutting down", sig)
+		if tb := Shutdown(); tb.HasError() {
+			writePanicReport(mainOutput, tb)
+		}
+		exitFunc(1)
+	case <-done:
+	}
+}
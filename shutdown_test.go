@@ -0,0 +1,71 @@
+package gotrycatch
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// ============================================
+// Shutdown 测试
+// ============================================
+
+func TestShutdown_RunsAllDespitePanic(t *testing.T) {
+	var order []int
+	OnShutdown(func() { order = append(order, 1) })
+	OnShutdown(func() { panic("cleanup failed") })
+	OnShutdown(func() { order = append(order, 3) })
+	OnShutdown(nil)
+
+	tb := Shutdown()
+
+	if len(order) != 2 || order[0] != 3 || order[1] != 1 {
+		t.Errorf("Expected cleanups to run in reverse order [3 1], got %v", order)
+	}
+	multi, ok := tb.GetError().(MultiError)
+	if !ok || len(multi.Errors) != 1 || multi.Errors[0] != "cleanup failed" {
+		t.Errorf("Expected MultiError with the cleanup panic, got %#v", tb.GetError())
+	}
+}
+
+func TestShutdown_ClearsHooks(t *testing.T) {
+	var runs int
+	OnShutdown(func() { runs++ })
+
+	if tb := Shutdown(); tb.HasError() {
+		t.Errorf("Expected clean block, got %v", tb.GetError())
+	}
+	Shutdown()
+
+	if runs != 1 {
+		t.Errorf("Expected cleanup to run once, got %d", runs)
+	}
+}
+
+func TestHandleShutdownSignal(t *testing.T) {
+	buf, code := interceptMain(t)
+
+	var ran bool
+	OnShutdown(func() { ran = true })
+	OnShutdown(func() { panic("flush failed") })
+
+	ch := make(chan os.Signal, 1)
+	ch <- os.Interrupt
+	handleShutdownSignal(ch, make(chan struct{}))
+
+	if !ran {
+		t.Error("Expected cleanup to run on signal")
+	}
+	if *code != 1 {
+		t.Errorf("Expected exit code 1, got %d", *code)
+	}
+	if !strings.Contains(buf.String(), "flush failed") {
+		t.Errorf("Expected cleanup panic in report, got %q", buf.String())
+	}
+}
+
+func TestHandleSignals_Stop(t *testing.T) {
+	stop := HandleSignals()
+	stop()
+	stop()
+}
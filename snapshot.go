@@ -0,0 +1,34 @@
+package gotrycatch
+
+// ============================================
+// SnapshotFunc - Environment context at recover time
+// ============================================
+
+// SnapshotFunc, when set, is called by Try each time it recovers a panic to capture
+// environment context such as the hostname, build version or request-scoped fields.
+// The result is stored on the TryBlock, available via Snapshot and included in
+// DumpPanic reports, so post-mortems carry context the throw site knows nothing about.
+// If SnapshotFunc panics, the block simply has no snapshot. It is nil by default.
+var SnapshotFunc func() map[string]string
+
+// takeSnapshot calls SnapshotFunc, returning nil if it panics.
+func takeSnapshot() (snapshot map[string]string) {
+	if r := recoverFrom(func() { snapshot = SnapshotFunc() }); r != nil {
+		debugLog("SnapshotFunc panicked with %T, skipping snapshot", r)
+		return nil
+	}
+	return snapshot
+}
+
+// Snapshot returns a copy of the environment snapshot taken when the block's panic was
+// recovered. Returns nil if SnapshotFunc was not set at the time or the TryBlock is nil.
+func (tb *TryBlock) Snapshot() map[string]string {
+	if tb == nil || tb.snapshot == nil {
+		return nil
+	}
+	snapshot := make(map[string]string, len(tb.snapshot))
+	for k, v := range tb.snapshot {
+		snapshot[k] = v
+	}
+	return snapshot
+}
@@ -0,0 +1,73 @@
+package gotrycatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// ============================================
+// SnapshotFunc 测试
+// ============================================
+
+func installSnapshot(t *testing.T, fn func() map[string]string) {
+	t.Helper()
+	original := SnapshotFunc
+	SnapshotFunc = fn
+	t.Cleanup(func() { SnapshotFunc = original })
+}
+
+func TestSnapshotFunc_InDump(t *testing.T) {
+	fixDumpTime(t)
+	installSnapshot(t, func() map[string]string {
+		return map[string]string{"hostname": "web-3", "version": "1.4.2"}
+	})
+
+	tb := Try(func() { panic("boom") })
+	if tb.Snapshot()["hostname"] != "web-3" {
+		t.Errorf("Expected snapshot on the block, got %v", tb.Snapshot())
+	}
+
+	var text bytes.Buffer
+	if err := DumpPanic(&text, tb, DumpText); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(text.String(), "environment:\n  hostname: web-3\n  version: 1.4.2\n") {
+		t.Errorf("Expected environment section in text dump, got:\n%s", text.String())
+	}
+
+	var buf bytes.Buffer
+	if err := DumpPanic(&buf, tb, DumpJSON); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var report CrashReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if report.Environment["version"] != "1.4.2" {
+		t.Errorf("Expected environment in JSON dump, got %v", report.Environment)
+	}
+}
+
+func TestSnapshotFunc_NotCalledWithoutPanic(t *testing.T) {
+	var calls int
+	installSnapshot(t, func() map[string]string {
+		calls++
+		return nil
+	})
+
+	Try(func() {})
+	if calls != 0 {
+		t.Errorf("Expected no snapshot for a clean block, got %d calls", calls)
+	}
+}
+
+func TestSnapshotFunc_Panicking(t *testing.T) {
+	installSnapshot(t, func() map[string]string { panic("snapshot failed") })
+
+	tb := Try(func() { panic("boom") })
+	if tb.GetError() != "boom" || tb.Snapshot() != nil {
+		t.Errorf("Expected original panic without snapshot, got %v and %v", tb.GetError(), tb.Snapshot())
+	}
+}
@@ -0,0 +1,46 @@
+package gotrycatch
+
+// ============================================
+// CatchSpan - Recording panics on tracing spans
+// ============================================
+
+// Span is the part of a tracing span that CatchSpan needs. It is deliberately small so
+// the library does not depend on OpenTelemetry; an otel span is adapted in a few lines:
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) RecordError(err error)             { s.Span.RecordError(err) }
+//	func (s otelSpan) SetErrorStatus(description string) { s.Span.SetStatus(codes.Error, description) }
+type Span interface {
+	// RecordError records err as an event on the span.
+	RecordError(err error)
+	// SetErrorStatus marks the span as failed with the given description.
+	SetErrorStatus(description string)
+}
+
+// CatchSpan handles any unhandled panic by recording it on span and setting the span's
+// status to error. Non-error panic values are converted to an error first.
+// Like CatchAny, it handles every type and should come last in a chain.
+// A nil span leaves the block unchanged.
+// Returns the same TryBlock to allow chaining.
+func CatchSpan(tb *TryBlock, span Span) *TryBlock {
+	if tb == nil {
+		debugLog("CatchSpan: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if span == nil {
+		debugLog("CatchSpan: span is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	if tb.err != nil && !tb.handled {
+		debugLog("CatchSpan: recording error of type %T on span", tb.err)
+		err := panicError(tb.err)
+		span.RecordError(err)
+		span.SetErrorStatus(err.Error())
+		tb.handled = true
+		tb.handledByAny = true
+	}
+	return tb
+}
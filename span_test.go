@@ -0,0 +1,65 @@
+package gotrycatch
+
+import (
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// CatchSpan 测试
+// ============================================
+
+type fakeSpan struct {
+	recorded []error
+	status   string
+}
+
+func (s *fakeSpan) RecordError(err error)             { s.recorded = append(s.recorded, err) }
+func (s *fakeSpan) SetErrorStatus(description string) { s.status = description }
+
+func TestCatchSpan_RecordsUnhandledError(t *testing.T) {
+	span := &fakeSpan{}
+	netErr := trycatcherrors.NewNetworkError("https://api.example.com", 502)
+
+	tb := CatchSpan(Try(func() { panic(netErr) }), span)
+
+	if !tb.IsHandled() {
+		t.Error("Expected block to be handled")
+	}
+	if len(span.recorded) != 1 || span.recorded[0].Error() != netErr.Error() {
+		t.Errorf("Expected the network error to be recorded, got %v", span.recorded)
+	}
+	if span.status != netErr.Error() {
+		t.Errorf("Expected error status %q, got %q", netErr.Error(), span.status)
+	}
+}
+
+func TestCatchSpan_NonErrorPanic(t *testing.T) {
+	span := &fakeSpan{}
+	CatchSpan(Try(func() { panic("boom") }), span)
+
+	if len(span.recorded) != 1 || span.status != "panic: boom" {
+		t.Errorf("Expected converted panic to be recorded, got %v with status %q", span.recorded, span.status)
+	}
+}
+
+func TestCatchSpan_SkipsHandledAndClean(t *testing.T) {
+	span := &fakeSpan{}
+
+	tb := Catch[string](Try(func() { panic("handled") }), func(string) {})
+	CatchSpan(tb, span)
+	CatchSpan(Try(func() {}), span)
+
+	if len(span.recorded) != 0 || span.status != "" {
+		t.Errorf("Expected span untouched, got %v with status %q", span.recorded, span.status)
+	}
+}
+
+func TestCatchSpan_NilSpan(t *testing.T) {
+	tb := CatchSpan(Try(func() { panic("boom") }), nil)
+
+	if tb.IsHandled() {
+		t.Error("Expected nil span to leave the block unhandled")
+	}
+}
@@ -0,0 +1,198 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// ============================================
+// Stack capture - Panic site stack and goroutine id
+// ============================================
+
+// CaptureStack enables recording of the panic stack and goroutine id when Try recovers
+// a panic. It is off by default because walking the stack is relatively expensive.
+var CaptureStack = false
+
+// MaxStackDepth bounds how many frames StackTrace reports for a captured panic.
+// Lower values make capture cheaper for deeply recursive code. Values below 1 use
+// the default of 64.
+var MaxStackDepth = 64
+
+// TrimLibraryFrames makes StackTrace drop the runtime frames and this package's throw
+// helpers (Throw, Assert, ...) at the top of a captured stack, so traces start at the
+// user code that raised the panic. It is off by default.
+var TrimLibraryFrames = false
+
+// panicPrefixFrames is the headroom captured beyond MaxStackDepth for the runtime and
+// throw helper frames that precede the panic site.
+const panicPrefixFrames = 8
+
+// maxStackDepth returns MaxStackDepth, or the default if it is not positive.
+func maxStackDepth() int {
+	if MaxStackDepth < 1 {
+		return 64
+	}
+	return MaxStackDepth
+}
+
+// capturePanicStack records the program counters of the panicking goroutine.
+// It must be called from the deferred function that recovered the panic, so that
+// the recorded stack still contains the panic site.
+func capturePanicStack() []uintptr {
+	pcs := make([]uintptr, maxStackDepth()+panicPrefixFrames)
+	// Skip runtime.Callers, capturePanicStack and the deferred recover function.
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// panicFrames resolves pcs into frames starting at the panic site,
+// dropping the runtime frames that precede it.
+func panicFrames(pcs []uintptr) []runtime.Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	var frames []runtime.Frame
+	iter := runtime.CallersFrames(pcs)
+	for {
+		frame, more := iter.Next()
+		frames = append(frames, frame)
+		if !more {
+			break
+		}
+	}
+
+	for i, frame := range frames {
+		if frame.Function == "runtime.gopanic" {
+			return frames[i+1:]
+		}
+	}
+	return frames
+}
+
+// StackTrace returns the stack of the captured panic, starting at the panic site,
+// formatted as "file:line function" like the built-in error types. At most
+// MaxStackDepth frames are returned; with TrimLibraryFrames the trace starts at user code.
+// Returns nil unless CaptureStack was enabled when the panic was recovered.
+func (tb *TryBlock) StackTrace() []string {
+	if tb == nil {
+		return nil
+	}
+
+	frames := panicFrames(tb.stack)
+	if TrimLibraryFrames {
+		for len(frames) > 0 && isLibraryFrame(frames[0]) {
+			frames = frames[1:]
+		}
+	}
+	if limit := maxStackDepth(); len(frames) > limit {
+		frames = frames[:limit]
+	}
+	if len(frames) == 0 {
+		return nil
+	}
+	trace := make([]string, len(frames))
+	for i, frame := range frames {
+		trace[i] = fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function)
+	}
+	return trace
+}
+
+// panicStack returns the best stack for the panic in tb: the captured panic stack
+// when CaptureStack was on, else the stack carried by the error value, if any.
+func panicStack(tb *TryBlock) []string {
+	if stack := tb.StackTrace(); len(stack) > 0 {
+		return stack
+	}
+	return stackOf(tb.err)
+}
+
+// GoroutineID returns the id of the goroutine on which the captured panic occurred.
+// Returns 0 unless CaptureStack was enabled when the panic was recovered.
+func (tb *TryBlock) GoroutineID() uint64 {
+	if tb == nil {
+		return 0
+	}
+	return tb.goroutineID
+}
+
+// throwHelpers are functions of this package that raise panics on behalf of their
+// caller. OriginPackage skips them so the origin is the code that asked to throw.
+var throwHelpers = map[string]bool{
+	"Throw":                              true,
+	"rethrow":                            true,
+	"Assert":                             true,
+	"Assertf":                            true,
+	"AssertNoError":                      true,
+	"ThrowCtx":                           true,
+	"ThrowLimited":                       true,
+	"(*Validator).Check":                 true,
+	"CatchMap[...]":                      true,
+	"CatchFinally[...]":                  true,
+	"wrapRethrow":                        true,
+	"(*TryBlock).Finally":                true,
+	"(*TryBlock).SafeFinally":            true,
+	"(*TryBlockWithResult[...]).Finally": true,
+	"(*OrChain).Finally":                 true,
+}
+
+// packagePath is the import path of this package, used to recognize throwHelpers.
+var packagePath = reflect.TypeOf(TryBlock{}).PkgPath()
+
+// OriginPackage returns the import path of the package whose code raised the captured
+// panic, for routing errors by subsystem. Runtime frames (such as those raising index
+// errors) and this package's throw helpers (Throw, Assert, ...) are skipped, so the
+// result is the package of the code that called them.
+// Returns "" unless CaptureStack was enabled when the panic was recovered.
+func OriginPackage(tb *TryBlock) string {
+	if tb == nil {
+		return ""
+	}
+
+	for _, frame := range panicFrames(tb.stack) {
+		if isLibraryFrame(frame) {
+			continue
+		}
+		pkg, _ := splitFuncName(frame.Function)
+		return pkg
+	}
+	return ""
+}
+
+// isLibraryFrame reports whether frame belongs to the runtime or to one of this
+// package's throw helpers rather than to the code that raised the panic.
+func isLibraryFrame(frame runtime.Frame) bool {
+	pkg, name := splitFuncName(frame.Function)
+	return pkg == "runtime" || (pkg == packagePath && throwHelpers[name])
+}
+
+// throwCaller returns the location of the first caller of Throw that is not one of
+// this package's throw helpers, as file:line, or "" if it cannot be determined.
+func throwCaller() string {
+	var pcs [16]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		pkg, name := splitFuncName(frame.Function)
+		if pkg != packagePath || !throwHelpers[name] {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// splitFuncName splits a fully qualified function name such as
+// "github.com/a/b.(*T).Method" into its package path and the rest.
+func splitFuncName(fn string) (pkg, name string) {
+	slash := strings.LastIndex(fn, "/")
+	dot := strings.Index(fn[slash+1:], ".")
+	if dot < 0 {
+		return fn, ""
+	}
+	return fn[:slash+1+dot], fn[slash+2+dot:]
+}
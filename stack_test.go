@@ -0,0 +1,183 @@
+package gotrycatch
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// ============================================
+// Stack capture 测试
+// ============================================
+
+func panicFromHelper() {
+	panic("helper failure")
+}
+
+func TestGoroutineID_Recorded(t *testing.T) {
+	CaptureStack = true
+	defer func() { CaptureStack = false }()
+
+	tb := Try(panicFromHelper)
+
+	if tb.GoroutineID() == 0 {
+		t.Error("Expected non-zero goroutine id for a panicked block")
+	}
+	if tb.GoroutineID() != goroutineID() {
+		t.Errorf("Expected goroutine id %d, got %d", goroutineID(), tb.GoroutineID())
+	}
+}
+
+func TestGoroutineID_OtherGoroutine(t *testing.T) {
+	CaptureStack = true
+	defer func() { CaptureStack = false }()
+
+	result := make(chan *TryBlock)
+	go func() { result <- Try(panicFromHelper) }()
+	tb := <-result
+
+	if tb.GoroutineID() == 0 || tb.GoroutineID() == goroutineID() {
+		t.Errorf("Expected id of the worker goroutine, got %d (test goroutine %d)", tb.GoroutineID(), goroutineID())
+	}
+}
+
+func TestStackTrace_StartsAtPanicSite(t *testing.T) {
+	CaptureStack = true
+	defer func() { CaptureStack = false }()
+
+	tb := Try(panicFromHelper)
+	trace := tb.StackTrace()
+
+	if len(trace) == 0 {
+		t.Fatal("Expected a captured stack trace")
+	}
+	if !strings.Contains(trace[0], "stack_test.go") || !strings.HasSuffix(trace[0], "gotrycatch.panicFromHelper") {
+		t.Errorf("Expected trace to start at panicFromHelper, got %s", trace[0])
+	}
+}
+
+func TestStackCapture_DisabledByDefault(t *testing.T) {
+	tb := Try(panicFromHelper)
+
+	if tb.GoroutineID() != 0 {
+		t.Errorf("Expected no goroutine id when disabled, got %d", tb.GoroutineID())
+	}
+	if tb.StackTrace() != nil {
+		t.Error("Expected no stack trace when disabled")
+	}
+
+	var nilBlock *TryBlock
+	if nilBlock.GoroutineID() != 0 || nilBlock.StackTrace() != nil {
+		t.Error("Expected zero values for nil TryBlock")
+	}
+}
+
+func recurseThenPanic(n int) {
+	if n == 0 {
+		Throw("deep failure")
+	}
+	recurseThenPanic(n - 1)
+}
+
+func TestStackTrace_MaxStackDepth(t *testing.T) {
+	CaptureStack, MaxStackDepth, TrimLibraryFrames = true, 3, true
+	defer func() { CaptureStack, MaxStackDepth, TrimLibraryFrames = false, 64, false }()
+
+	trace := Try(func() { recurseThenPanic(20) }).StackTrace()
+
+	if len(trace) != 3 {
+		t.Fatalf("Expected trace bounded to 3 frames, got %d: %v", len(trace), trace)
+	}
+	for _, frame := range trace {
+		if !strings.HasSuffix(frame, "gotrycatch.recurseThenPanic") {
+			t.Errorf("Expected only user frames, got %s", frame)
+		}
+	}
+}
+
+func TestStackTrace_TrimLibraryFramesOff(t *testing.T) {
+	CaptureStack = true
+	defer func() { CaptureStack = false }()
+
+	trace := Try(func() { recurseThenPanic(0) }).StackTrace()
+
+	if len(trace) == 0 || !strings.HasSuffix(trace[0], "gotrycatch.Throw") {
+		t.Errorf("Expected trace to start at Throw without trimming, got %v", trace)
+	}
+}
+
+func TestMaxStackDepth_NonPositiveUsesDefault(t *testing.T) {
+	MaxStackDepth = 0
+	defer func() { MaxStackDepth = 64 }()
+
+	if got := maxStackDepth(); got != 64 {
+		t.Errorf("Expected default depth 64, got %d", got)
+	}
+}
+
+// ============================================
+// OriginPackage 测试
+// ============================================
+
+func TestOriginPackage(t *testing.T) {
+	CaptureStack = true
+	defer func() { CaptureStack = false }()
+
+	tests := []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{"std package helper", func() { regexp.MustCompile("(") }, "regexp"},
+		{"direct panic", panicFromHelper, packagePath},
+		{"Throw", func() { Throw("thrown") }, packagePath},
+		{"Assertf", func() { Assertf(false, "bad") }, packagePath},
+		{"runtime error", func() {
+			var s []int
+			_ = s[3]
+		}, packagePath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OriginPackage(Try(tt.fn)); got != tt.want {
+				t.Errorf("Expected origin %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOriginPackage_SkipsThrowHelpers(t *testing.T) {
+	CaptureStack = true
+	defer func() { CaptureStack = false }()
+
+	tb := Try(func() { Throw("thrown") })
+
+	frames := panicFrames(tb.stack)
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, ".Throw") {
+		t.Fatalf("Expected the first panic frame to be Throw, got %v", tb.StackTrace())
+	}
+}
+
+func TestOriginPackage_NoStack(t *testing.T) {
+	if got := OriginPackage(Try(panicFromHelper)); got != "" {
+		t.Errorf("Expected empty origin without CaptureStack, got %q", got)
+	}
+	if OriginPackage(nil) != "" {
+		t.Error("Expected empty origin for nil block")
+	}
+}
+
+func TestSplitFuncName(t *testing.T) {
+	tests := []struct{ fn, pkg, name string }{
+		{"github.com/a/b.(*T).Method", "github.com/a/b", "(*T).Method"},
+		{"github.com/a/b.Func.func1", "github.com/a/b", "Func.func1"},
+		{"main.main", "main", "main"},
+		{"regexp.MustCompile", "regexp", "MustCompile"},
+	}
+	for _, tt := range tests {
+		if pkg, name := splitFuncName(tt.fn); pkg != tt.pkg || name != tt.name {
+			t.Errorf("splitFuncName(%q) = %q, %q; want %q, %q", tt.fn, pkg, name, tt.pkg, tt.name)
+		}
+	}
+}
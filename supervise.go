@@ -0,0 +1,90 @@
+package gotrycatch
+
+import (
+	"context"
+	"time"
+)
+
+// ============================================
+// Supervise - Restarting panicking workers
+// ============================================
+
+// RestartPolicy controls how Supervise restarts a panicking worker.
+type RestartPolicy struct {
+	// MaxRestarts is the number of restarts allowed before giving up.
+	// Zero means the worker is never restarted; a negative value means no limit.
+	MaxRestarts int
+	// Backoff computes the delay before each restart, using the restart number as
+	// attempt. If it reports stop, supervision ends. A nil Backoff restarts immediately.
+	Backoff Strategy
+}
+
+// SupervisorStats describes how a supervised worker ended.
+type SupervisorStats struct {
+	Restarts  int         // Number of times the worker was restarted
+	Panics    int         // Number of panics recovered
+	LastPanic interface{} // Most recent panic value, or nil if the worker never panicked
+	Completed bool        // The worker returned normally
+	Exhausted bool        // Supervision stopped because the policy allowed no more restarts
+}
+
+// Supervise runs worker and restarts it whenever it panics, according to policy.
+// It returns when the worker returns normally, when the policy is exhausted, or when
+// ctx is cancelled, whichever happens first. Cancellation is checked between runs and
+// during backoff delays; a running worker is not interrupted.
+func Supervise(ctx context.Context, worker func(), policy RestartPolicy) SupervisorStats {
+	var stats SupervisorStats
+
+	for {
+		if ctx.Err() != nil {
+			debugLog("Supervise: context cancelled after %d restart(s)", stats.Restarts)
+			return stats
+		}
+
+		tb := Try(worker)
+		if !tb.HasError() {
+			stats.Completed = true
+			return stats
+		}
+		stats.Panics++
+		stats.LastPanic = tb.err
+
+		if policy.MaxRestarts >= 0 && stats.Restarts >= policy.MaxRestarts {
+			debugLog("Supervise: giving up after %d restart(s), last panic %T", stats.Restarts, tb.err)
+			stats.Exhausted = true
+			return stats
+		}
+
+		var delay time.Duration
+		if policy.Backoff != nil {
+			var stop bool
+			delay, stop = policy.Backoff.NextDelay(stats.Restarts+1, tb.err)
+			if stop {
+				debugLog("Supervise: backoff strategy stopped after %d restart(s)", stats.Restarts)
+				stats.Exhausted = true
+				return stats
+			}
+		}
+
+		debugLog("Supervise: worker panicked with %T, restarting in %v", tb.err, delay)
+		if !waitContext(ctx, delay) {
+			return stats
+		}
+		stats.Restarts++
+	}
+}
+
+// waitContext waits for d or until ctx is done, and reports whether the full delay elapsed.
+func waitContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
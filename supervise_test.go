@@ -0,0 +1,83 @@
+package gotrycatch
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// ============================================
+// Supervise 测试
+// ============================================
+
+func TestSupervise_RestartsUntilStable(t *testing.T) {
+	var runs int
+	stats := Supervise(context.Background(), func() {
+		runs++
+		if runs <= 3 {
+			panic(runs)
+		}
+	}, RestartPolicy{MaxRestarts: 5, Backoff: FixedDelay{Delay: time.Millisecond, MaxAttempts: 10}})
+
+	if runs != 4 {
+		t.Errorf("Expected 4 runs, got %d", runs)
+	}
+	if stats.Restarts != 3 || stats.Panics != 3 {
+		t.Errorf("Expected 3 restarts and 3 panics, got %+v", stats)
+	}
+	if !stats.Completed || stats.Exhausted {
+		t.Errorf("Expected worker to complete, got %+v", stats)
+	}
+	if stats.LastPanic != 3 {
+		t.Errorf("Expected last panic 3, got %v", stats.LastPanic)
+	}
+}
+
+func TestSupervise_MaxRestarts(t *testing.T) {
+	var runs int
+	stats := Supervise(context.Background(), func() {
+		runs++
+		panic("always")
+	}, RestartPolicy{MaxRestarts: 2})
+
+	if runs != 3 {
+		t.Errorf("Expected initial run plus 2 restarts, got %d runs", runs)
+	}
+	if !stats.Exhausted || stats.Completed {
+		t.Errorf("Expected exhausted stats, got %+v", stats)
+	}
+}
+
+func TestSupervise_BackoffStop(t *testing.T) {
+	stats := Supervise(context.Background(), func() {
+		panic("always")
+	}, RestartPolicy{MaxRestarts: -1, Backoff: FixedDelay{MaxAttempts: 3}})
+
+	if stats.Restarts != 2 || !stats.Exhausted {
+		t.Errorf("Expected backoff to stop after 2 restarts, got %+v", stats)
+	}
+}
+
+func TestSupervise_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan SupervisorStats)
+	go func() {
+		done <- Supervise(ctx, func() {
+			panic("always")
+		}, RestartPolicy{MaxRestarts: -1, Backoff: FixedDelay{Delay: time.Hour, MaxAttempts: 100}})
+	}()
+
+	// The first backoff is an hour long, so cancelling must interrupt it.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case stats := <-done:
+		if stats.Completed || stats.Exhausted {
+			t.Errorf("Expected cancellation, got %+v", stats)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected Supervise to return after cancellation")
+	}
+}
@@ -0,0 +1,81 @@
+package gotrycatch
+
+// ============================================
+// HandlerTable - Declarative handling policy
+// ============================================
+
+// HandlerTable is a reusable, declarative set of typed handlers, built once and
+// dispatched to from many Try blocks so handling policy lives in one place:
+//
+//	var policy = gotrycatch.NewHandlerTable().
+//		Add(gotrycatch.On(func(err errors.ValidationError) { ... })).
+//		Add(gotrycatch.On(func(err errors.DatabaseError) { ... })).
+//		Default(func(err interface{}) { ... })
+//
+//	gotrycatch.Try(work).Dispatch(policy).Finally(cleanup)
+//
+// Handlers are built with On, as for OrChain.Or, because Go methods cannot take type
+// parameters. A table must not be modified while it is being dispatched to; once built,
+// it is safe to dispatch to from multiple goroutines.
+type HandlerTable struct {
+	handlers []Handler
+	fallback func(interface{})
+}
+
+// NewHandlerTable returns an empty table.
+func NewHandlerTable() *HandlerTable {
+	return &HandlerTable{}
+}
+
+// Add appends handlers to the table. They are tried in the order they were added.
+// Nil handlers, including On with a nil func, are ignored. Returns the table to allow chaining.
+func (t *HandlerTable) Add(handlers ...Handler) *HandlerTable {
+	for _, h := range handlers {
+		if h != nil {
+			t.handlers = append(t.handlers, h)
+		}
+	}
+	return t
+}
+
+// Default sets the handler for values no typed handler matches, replacing any earlier
+// one. Returns the table to allow chaining.
+func (t *HandlerTable) Default(handler func(interface{})) *HandlerTable {
+	t.fallback = handler
+	return t
+}
+
+// Dispatch handles the block's unhandled error with the first matching handler in
+// table, or with its default handler if none matches, and marks the block handled.
+// If nothing matches and there is no default, the block stays unhandled.
+// A nil table leaves the block unchanged.
+// Returns the same TryBlock to allow chaining.
+func (tb *TryBlock) Dispatch(table *HandlerTable) *TryBlock {
+	if tb == nil {
+		debugLog("Dispatch: TryBlock is nil, returning empty TryBlock")
+		return &TryBlock{}
+	}
+
+	if table == nil {
+		debugLog("Dispatch: table is nil, returning TryBlock unchanged")
+		return tb
+	}
+
+	for i, h := range table.handlers {
+		noteCatchTarget(tb, "Dispatch", h.target())
+		if tb.err != nil && !tb.handled && h.handle(tb.err) {
+			debugLog("Dispatch: handler %d matched %T", i, tb.err)
+			tb.handled = true
+			return tb
+		}
+	}
+	if tb.err == nil || tb.handled {
+		return tb
+	}
+	if table.fallback != nil {
+		debugLog("Dispatch: no handler matched %T, using default", tb.err)
+		return tb.CatchAny(table.fallback)
+	}
+	debugLog("Dispatch: no handler matched %T", tb.err)
+	return tb
+}
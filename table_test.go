@@ -0,0 +1,79 @@
+package gotrycatch
+
+import (
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// HandlerTable 测试
+// ============================================
+
+func TestHandlerTable_Dispatch(t *testing.T) {
+	var got []string
+	table := NewHandlerTable().
+		Add(On(func(trycatcherrors.ValidationError) { got = append(got, "validation") })).
+		Add(On(func(trycatcherrors.DatabaseError) { got = append(got, "database") }), nil).
+		Add(On(func(error) { got = append(got, "error") })).
+		Default(func(interface{}) { got = append(got, "default") })
+
+	values := []interface{}{
+		trycatcherrors.NewValidationError("email", "invalid", 1001),
+		trycatcherrors.NewDatabaseError("SELECT", "users", nil),
+		trycatcherrors.NewNetworkError("https://api.example.com", 503),
+		"plain",
+	}
+	for _, v := range values {
+		if tb := Try(func() { panic(v) }).Dispatch(table); !tb.IsHandled() {
+			t.Errorf("Expected %T to be handled", v)
+		}
+	}
+
+	want := []string{"validation", "database", "error", "default"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Dispatch %d: expected %s, got %s", i, want[i], got[i])
+		}
+	}
+}
+
+func TestHandlerTable_NoMatchWithoutDefault(t *testing.T) {
+	table := NewHandlerTable().Add(On(func(int) {}))
+
+	if tb := Try(func() { panic("x") }).Dispatch(table); tb.IsHandled() {
+		t.Error("Expected block to stay unhandled")
+	}
+	if tb := Try(func() { panic("x") }).Dispatch(nil); tb.IsHandled() {
+		t.Error("Expected nil table to leave the block unhandled")
+	}
+}
+
+func TestHandlerTable_SkipsHandledAndClean(t *testing.T) {
+	var calls int
+	table := NewHandlerTable().Default(func(interface{}) { calls++ })
+
+	Try(func() {}).Dispatch(table)
+	Catch[string](Try(func() { panic("x") }), func(string) {}).Dispatch(table)
+
+	if calls != 0 {
+		t.Errorf("Expected no handler calls, got %d", calls)
+	}
+}
+
+func TestHandlerTable_NilTypedHandler(t *testing.T) {
+	var got string
+	table := NewHandlerTable().
+		Add(On[string](nil), On(func(s string) { got = s }))
+
+	var tb *TryBlock
+	if SuppressPanics(func() { tb = Try(func() { panic("x") }).Dispatch(table) }) {
+		t.Fatal("Expected a nil typed handler to be ignored, not called")
+	}
+	if !tb.IsHandled() || got != "x" {
+		t.Errorf("Expected the next string handler to fire, got handled=%v value=%q", tb.IsHandled(), got)
+	}
+}
@@ -0,0 +1,36 @@
+package gotrycatch
+
+import (
+	"sync"
+	"time"
+)
+
+// ============================================
+// ThrowLimited - Rate-limited throws
+// ============================================
+
+var (
+	throttleMu  sync.Mutex
+	lastThrows  = map[string]time.Time{} // time of the last actual throw per key
+	throttleNow = time.Now               // injectable clock for tests
+)
+
+// ThrowLimited throws err like Throw, but at most once per d for the given key: if the
+// last throw for key happened less than d ago, it returns silently instead. This keeps
+// defensive checks in tight loops from flooding downstream handlers with the same error.
+// Keys are kept for the life of the process, so use a small, fixed set of them.
+func ThrowLimited(key string, d time.Duration, err interface{}) {
+	now := throttleNow()
+
+	throttleMu.Lock()
+	last, seen := lastThrows[key]
+	if seen && now.Sub(last) < d {
+		throttleMu.Unlock()
+		debugLog("ThrowLimited: suppressed throw for key %q", key)
+		return
+	}
+	lastThrows[key] = now
+	throttleMu.Unlock()
+
+	Throw(err)
+}
@@ -0,0 +1,62 @@
+package gotrycatch
+
+import (
+	"testing"
+	"time"
+)
+
+// ============================================
+// ThrowLimited 测试
+// ============================================
+
+func useThrottleClock(t *testing.T) *fakeClock {
+	clock := newFakeClock()
+	throttleNow = clock.Now
+	t.Cleanup(func() {
+		throttleNow = time.Now
+		throttleMu.Lock()
+		lastThrows = map[string]time.Time{}
+		throttleMu.Unlock()
+	})
+	return clock
+}
+
+func TestThrowLimited_OncePerInterval(t *testing.T) {
+	clock := useThrottleClock(t)
+
+	var thrown int
+	for i := 0; i < 100; i++ {
+		if Try(func() { ThrowLimited("db-down", time.Second, "db down") }).HasError() {
+			thrown++
+		}
+		clock.Advance(25 * time.Millisecond) // 100 calls span 2.5s
+	}
+
+	if thrown != 3 {
+		t.Errorf("Expected 3 throws over 2.5s with a 1s interval, got %d", thrown)
+	}
+}
+
+func TestThrowLimited_KeysAreIndependent(t *testing.T) {
+	useThrottleClock(t)
+
+	if !Try(func() { ThrowLimited("a", time.Minute, "a failed") }).HasError() {
+		t.Error("Expected first throw for key a")
+	}
+	if !Try(func() { ThrowLimited("b", time.Minute, "b failed") }).HasError() {
+		t.Error("Expected first throw for key b")
+	}
+	if Try(func() { ThrowLimited("a", time.Minute, "a failed") }).HasError() {
+		t.Error("Expected repeated throw for key a to be suppressed")
+	}
+}
+
+func TestThrowLimited_ThrowsValue(t *testing.T) {
+	useThrottleClock(t)
+
+	tb := Try(func() { ThrowLimited("k", time.Second, "boom") })
+
+	if tb.GetError() != "boom" {
+		t.Errorf("Expected thrown value, got %v", tb.GetError())
+	}
+}
@@ -0,0 +1,70 @@
+package gotrycatch
+
+import (
+	"reflect"
+	"sync"
+)
+
+// ============================================
+// Translators - Mapping third-party panic types
+// ============================================
+
+// Translator converts a recovered panic value into the value Try should capture,
+// typically one of the built-in error types.
+type Translator func(v interface{}) interface{}
+
+var (
+	translatorsMu sync.RWMutex
+	translators   = map[reflect.Type]Translator{}
+)
+
+// RegisterTranslator makes Try, and every other recover in the package, capture
+// translate(v) in place of a panic value v of the same dynamic type as sample. It
+// integrates libraries that panic with their own types, so they can be caught as
+// gotrycatch errors:
+//
+//	gotrycatch.RegisterTranslator((*json.SyntaxError)(nil), func(v interface{}) interface{} {
+//		e := v.(*json.SyntaxError)
+//		return errors.NewValidationError("body", e.Error(), 400)
+//	})
+//
+// Registering a type again replaces its translator; a nil translate removes it.
+// A nil sample is ignored. Translation is applied once, not to its own result.
+func RegisterTranslator(sample interface{}, translate Translator) {
+	if sample == nil {
+		debugLog("RegisterTranslator: sample is nil, ignoring")
+		return
+	}
+
+	t := reflect.TypeOf(sample)
+	translatorsMu.Lock()
+	defer translatorsMu.Unlock()
+	if translate == nil {
+		delete(translators, t)
+		return
+	}
+	translators[t] = translate
+}
+
+// translatePanic applies the translator registered for the type of r, if any.
+// If the translator panics or returns nil, r is kept unchanged.
+func translatePanic(r interface{}) (result interface{}) {
+	translatorsMu.RLock()
+	translate := translators[reflect.TypeOf(r)]
+	translatorsMu.RUnlock()
+	if translate == nil {
+		return r
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			debugLog("Translator: translator for %T panicked with %T, keeping original value", r, p)
+			result = r
+		}
+	}()
+	if translated := translate(r); translated != nil {
+		debugLog("Translator: translated %T into %T", r, translated)
+		return translated
+	}
+	return r
+}
@@ -0,0 +1,74 @@
+package gotrycatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Translator 测试
+// ============================================
+
+func TestRegisterTranslator_JSONSyntaxError(t *testing.T) {
+	RegisterTranslator((*json.SyntaxError)(nil), func(v interface{}) interface{} {
+		e := v.(*json.SyntaxError)
+		return trycatcherrors.NewValidationError("body", e.Error(), 400)
+	})
+	t.Cleanup(func() { RegisterTranslator((*json.SyntaxError)(nil), nil) })
+
+	tb := Try(func() {
+		var v map[string]interface{}
+		if err := json.Unmarshal([]byte("{bad"), &v); err != nil {
+			panic(err)
+		}
+	})
+
+	var caught trycatcherrors.ValidationError
+	Catch[trycatcherrors.ValidationError](tb, func(e trycatcherrors.ValidationError) { caught = e })
+	if caught.Field != "body" || caught.Code != 400 {
+		t.Errorf("Expected translated ValidationError, got %T: %v", tb.GetError(), tb.GetError())
+	}
+}
+
+func TestRegisterTranslator_AppliesToTryFastAndResult(t *testing.T) {
+	type legacyPanic struct{ msg string }
+	RegisterTranslator(legacyPanic{}, func(v interface{}) interface{} {
+		return trycatcherrors.NewBusinessLogicError("legacy", v.(legacyPanic).msg)
+	})
+	t.Cleanup(func() { RegisterTranslator(legacyPanic{}, nil) })
+
+	if _, value := TryFast(func() { panic(legacyPanic{"x"}) }); !isBusinessLogicError(value) {
+		t.Errorf("Expected TryFast to capture translated value, got %T", value)
+	}
+	tb := TryWithResult(func() int { panic(legacyPanic{"y"}) })
+	if !isBusinessLogicError(tb.GetError()) {
+		t.Errorf("Expected TryWithResult to capture translated value, got %T", tb.GetError())
+	}
+}
+
+func isBusinessLogicError(v interface{}) bool {
+	_, ok := v.(trycatcherrors.BusinessLogicError)
+	return ok
+}
+
+func TestRegisterTranslator_FaultyTranslatorKeepsOriginal(t *testing.T) {
+	type flaky struct{}
+	RegisterTranslator(flaky{}, func(interface{}) interface{} { panic("translator bug") })
+
+	if tb := Try(func() { panic(flaky{}) }); tb.GetError() != (flaky{}) {
+		t.Errorf("Expected original value when translator panics, got %v", tb.GetError())
+	}
+
+	RegisterTranslator(flaky{}, func(interface{}) interface{} { return nil })
+	if tb := Try(func() { panic(flaky{}) }); tb.GetError() != (flaky{}) {
+		t.Errorf("Expected original value when translator returns nil, got %v", tb.GetError())
+	}
+
+	RegisterTranslator(flaky{}, nil)
+	RegisterTranslator(nil, func(v interface{}) interface{} { return v })
+	if tb := Try(func() { panic("untouched") }); tb.GetError() != "untouched" {
+		t.Errorf("Expected unregistered types to pass through, got %v", tb.GetError())
+	}
+}
@@ -0,0 +1,202 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"time"
+)
+
+// ============================================
+// TryTimed - Try with execution duration
+// ============================================
+
+// TryTimed executes the given function like Try and also measures how long it ran.
+// The duration covers the time until fn returned or panicked.
+// The duration is also recorded on the returned TryBlock and available via Duration.
+func TryTimed(fn func()) (*TryBlock, time.Duration) {
+	start := time.Now()
+	tb := Try(fn)
+	tb.duration = time.Since(start)
+	debugLog("TryTimed: function ran for %v", tb.duration)
+	return tb, tb.duration
+}
+
+// Duration returns how long the protected function ran.
+// Only blocks created by TryTimed record a duration; other blocks return 0.
+// Returns 0 if the TryBlock itself is nil.
+func (tb *TryBlock) Duration() time.Duration {
+	if tb == nil {
+		return 0
+	}
+	return tb.duration
+}
+
+// ============================================
+// SuppressPanics - Allocation-free panic check
+// ============================================
+
+// SuppressPanics runs fn, silently recovers any panic, and reports whether one occurred.
+// Unlike Try it does not allocate a TryBlock, so the non-panicking path is allocation-free.
+// This is intended for benchmarks and hot paths that only need to know if fn panicked.
+func SuppressPanics(fn func()) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			debugLog("SuppressPanics: suppressed panic of type %T: %v", r, r)
+			panicked = true
+		}
+	}()
+	fn()
+	return false
+}
+
+// TryFast runs fn and returns whether it panicked together with the recovered value.
+// Like SuppressPanics it does not allocate a TryBlock, so the non-panicking path is
+// allocation-free; use it on hot paths that need the value but not the catch chain.
+func TryFast(fn func()) (panicked bool, value interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			debugLog("TryFast: recovered panic of type %T: %v", r, r)
+			panicked, value = true, normalizePanic(r, false)
+		}
+	}()
+	fn()
+	return false, nil
+}
+
+// ============================================
+// TrySeq - Sequential steps
+// ============================================
+
+// TrySeq runs the given functions in order, stopping at the first one that panics.
+// The panic is captured in the returned TryBlock and the index of the failing function
+// is available via FailedStep. If every function succeeds, a clean TryBlock is returned.
+func TrySeq(fns ...func()) *TryBlock {
+	for i, fn := range fns {
+		if fn == nil {
+			continue
+		}
+		tb := Try(fn)
+		if tb.HasError() {
+			tb.failedStep = i + 1
+			debugLog("TrySeq: step %d panicked with %T", i, tb.err)
+			return tb
+		}
+	}
+	return &TryBlock{}
+}
+
+// FailedStep returns the zero-based index of the TrySeq or TryCheckpoint step that panicked.
+// Returns -1 if no step failed, the block was not created by either, or the TryBlock is nil.
+func (tb *TryBlock) FailedStep() int {
+	if tb == nil {
+		return -1
+	}
+	return tb.failedStep - 1
+}
+
+// ============================================
+// TryWithFinally - Try with guaranteed cleanup
+// ============================================
+
+// TryWithFinally executes fn like Try and always runs cleanup, even if the caller
+// abandons the chain and never calls Finally. Cleanup is registered before fn runs
+// and executes as soon as fn returns or panics, so it runs before any Catch handler.
+// If cleanup itself panics while fn's panic is in flight, the block holds both values
+// as a MultiError (fn's first), as SafeFinally does; if only cleanup panics, the block
+// holds that panic. A nil cleanup makes TryWithFinally equivalent to Try.
+func TryWithFinally(fn func(), cleanup func()) *TryBlock {
+	if cleanup == nil {
+		debugLog("TryWithFinally: cleanup is nil, behaving like Try")
+		return Try(fn)
+	}
+	return Try(func() {
+		defer func() {
+			cleanupErr := recoverFrom(cleanup)
+			if cleanupErr == nil {
+				return
+			}
+			if fnErr := normalizePanic(recover(), true); fnErr != nil {
+				debugLog("TryWithFinally: cleanup panicked with %T while fn panicked with %T, merging", cleanupErr, fnErr)
+				panic(mergeRethrow(fnErr, cleanupErr))
+			}
+			panic(cleanupErr)
+		}()
+		fn()
+	})
+}
+
+// ============================================
+// Safe / SafeErr - Panic-safe function wrappers
+// ============================================
+
+// Safe returns a function that runs fn and silently recovers any panic, for passing
+// callbacks to libraries that do not expect panics. Panics are logged in debug mode.
+func Safe(fn func()) func() {
+	return func() {
+		if panicked, value := TryFast(fn); panicked {
+			debugLog("Safe: recovered panic of type %T: %v", value, value)
+		}
+	}
+}
+
+// SafeErr returns a function that runs fn and converts a panic into a returned error.
+// Panic values that are errors are returned unchanged; other values are wrapped in an
+// error with a "panic: " prefix. Errors returned by fn itself pass through.
+func SafeErr(fn func() error) func() error {
+	return func() (err error) {
+		panicked, value := TryFast(func() { err = fn() })
+		if panicked {
+			return panicError(value)
+		}
+		return err
+	}
+}
+
+// WrapTask returns a task that runs fn and passes any panic to onPanic instead of
+// letting it crash the worker, for submitting to goroutine pools such as ants that do
+// not recover task panics themselves. A nil onPanic drops the panic, like Safe.
+func WrapTask(fn func(), onPanic func(interface{})) func() {
+	return func() {
+		panicked, value := TryFast(fn)
+		if !panicked {
+			return
+		}
+		debugLog("WrapTask: task panicked with %T", value)
+		if onPanic != nil {
+			onPanic(value)
+		}
+	}
+}
+
+// panicError converts a recovered panic value into an error.
+func panicError(value interface{}) error {
+	if err, ok := value.(error); ok {
+		return err
+	}
+	return fmt.Errorf("panic: %v", value)
+}
+
+// ============================================
+// TryPartial - Partial results of failing producers
+// ============================================
+
+// TryPartial runs fn, collecting the values it passes to emit, and returns them with
+// a TryBlock capturing any panic. If fn panics, the values emitted before the panic are
+// still returned, so callers can use what was produced before the failure.
+// emit must only be called from fn's goroutine, and not after fn returns.
+func TryPartial[T any](fn func(emit func(T))) ([]T, *TryBlock) {
+	var values []T
+	tb := Try(func() {
+		fn(func(v T) { values = append(values, v) })
+	})
+	if tb.err != nil {
+		debugLog("TryPartial: panicked after emitting %d value(s)", len(values))
+	}
+	return values, tb
+}
+
+// TryCapture runs fn like Try, collecting the lines it passes to log, and returns them
+// with the TryBlock. Lines logged before a panic are kept, so they can be attached to
+// the failure as context. log must only be called from fn's goroutine.
+func TryCapture(fn func(log func(string))) ([]string, *TryBlock) {
+	return TryPartial(fn)
+}
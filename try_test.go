@@ -0,0 +1,393 @@
+package gotrycatch
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ============================================
+// TryTimed 测试
+// ============================================
+
+func TestTryTimed_NoPanic(t *testing.T) {
+	tb, d := TryTimed(func() {
+		time.Sleep(20 * time.Millisecond)
+	})
+
+	if tb.HasError() {
+		t.Errorf("Expected no error, got %v", tb.GetError())
+	}
+	if d < 20*time.Millisecond {
+		t.Errorf("Expected duration >= 20ms, got %v", d)
+	}
+	if d > 2*time.Second {
+		t.Errorf("Expected duration to roughly match the sleep, got %v", d)
+	}
+	if tb.Duration() != d {
+		t.Errorf("Expected Duration() %v to equal returned duration %v", tb.Duration(), d)
+	}
+}
+
+func TestTryTimed_WithPanic(t *testing.T) {
+	tb, d := TryTimed(func() {
+		time.Sleep(10 * time.Millisecond)
+		panic("slow failure")
+	})
+
+	if tb.GetError() != "slow failure" {
+		t.Errorf("Expected error 'slow failure', got %v", tb.GetError())
+	}
+	if d < 10*time.Millisecond {
+		t.Errorf("Expected duration >= 10ms, got %v", d)
+	}
+}
+
+func TestDuration_Untimed(t *testing.T) {
+	tb := Try(func() {})
+	if tb.Duration() != 0 {
+		t.Errorf("Expected zero duration for untimed block, got %v", tb.Duration())
+	}
+
+	var nilBlock *TryBlock
+	if nilBlock.Duration() != 0 {
+		t.Error("Expected zero duration for nil TryBlock")
+	}
+}
+
+// ============================================
+// SuppressPanics 测试
+// ============================================
+
+func TestSuppressPanics(t *testing.T) {
+	if SuppressPanics(func() {}) {
+		t.Error("Expected false for a function that does not panic")
+	}
+	if !SuppressPanics(func() { panic("boom") }) {
+		t.Error("Expected true for a panicking function")
+	}
+}
+
+func TestSuppressPanics_NoAllocOnHappyPath(t *testing.T) {
+	noop := func() {}
+	allocs := testing.AllocsPerRun(100, func() {
+		SuppressPanics(noop)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected zero allocations, got %v", allocs)
+	}
+}
+
+func TestTryFast(t *testing.T) {
+	if panicked, value := TryFast(func() {}); panicked || value != nil {
+		t.Errorf("Expected (false, nil), got (%v, %v)", panicked, value)
+	}
+
+	panicked, value := TryFast(func() { panic("boom") })
+	if !panicked || value != "boom" {
+		t.Errorf("Expected (true, boom), got (%v, %v)", panicked, value)
+	}
+}
+
+func TestTryFast_NoAllocOnHappyPath(t *testing.T) {
+	noop := func() {}
+	allocs := testing.AllocsPerRun(100, func() {
+		TryFast(noop)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected zero allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkTryFast_NoPanic(b *testing.B) {
+	noop := func() {}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		TryFast(noop)
+	}
+}
+
+func BenchmarkTry_NoPanic(b *testing.B) {
+	noop := func() {}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Try(noop)
+	}
+}
+
+// ============================================
+// TrySeq 测试
+// ============================================
+
+func TestTrySeq_AllSucceed(t *testing.T) {
+	var order []int
+	tb := TrySeq(
+		func() { order = append(order, 0) },
+		func() { order = append(order, 1) },
+		func() { order = append(order, 2) },
+	)
+
+	if tb.HasError() {
+		t.Errorf("Expected clean block, got %v", tb.GetError())
+	}
+	if tb.FailedStep() != -1 {
+		t.Errorf("Expected FailedStep -1, got %d", tb.FailedStep())
+	}
+	if len(order) != 3 || order[0] != 0 || order[2] != 2 {
+		t.Errorf("Expected steps to run in order, got %v", order)
+	}
+}
+
+func TestTrySeq_StopsAtFirstFailure(t *testing.T) {
+	var ran []int
+	tb := TrySeq(
+		func() { ran = append(ran, 0) },
+		func() { ran = append(ran, 1); panic("step 1 failed") },
+		func() { ran = append(ran, 2) },
+	)
+
+	if tb.GetError() != "step 1 failed" {
+		t.Errorf("Expected 'step 1 failed', got %v", tb.GetError())
+	}
+	if tb.FailedStep() != 1 {
+		t.Errorf("Expected FailedStep 1, got %d", tb.FailedStep())
+	}
+	if len(ran) != 2 {
+		t.Errorf("Expected execution to stop after step 1, got %v", ran)
+	}
+
+	tb = Catch[string](tb, func(err string) {})
+	if !tb.IsHandled() {
+		t.Error("Expected TrySeq block to work with Catch")
+	}
+}
+
+func TestTrySeq_Empty(t *testing.T) {
+	if tb := TrySeq(); tb.HasError() || tb.FailedStep() != -1 {
+		t.Error("Expected clean block for no steps")
+	}
+
+	var nilBlock *TryBlock
+	if nilBlock.FailedStep() != -1 {
+		t.Error("Expected -1 for nil TryBlock")
+	}
+	if Try(func() { panic("x") }).FailedStep() != -1 {
+		t.Error("Expected -1 for a block not created by TrySeq")
+	}
+}
+
+// ============================================
+// TryWithFinally 测试
+// ============================================
+
+func TestTryWithFinally_CleanPath(t *testing.T) {
+	var ran, cleaned bool
+
+	tb := TryWithFinally(func() { ran = true }, func() { cleaned = true })
+
+	if !ran || !cleaned {
+		t.Errorf("Expected fn and cleanup to run, got ran=%v cleaned=%v", ran, cleaned)
+	}
+	if tb.HasError() {
+		t.Errorf("Expected no error, got %v", tb.GetError())
+	}
+}
+
+func TestTryWithFinally_PanicPath(t *testing.T) {
+	var cleaned bool
+
+	// The chain is deliberately abandoned: no Catch and no Finally.
+	tb := TryWithFinally(func() { panic("boom") }, func() { cleaned = true })
+
+	if !cleaned {
+		t.Error("Expected cleanup to run even though Finally was never called")
+	}
+	if tb.GetError() != "boom" {
+		t.Errorf("Expected 'boom', got %v", tb.GetError())
+	}
+}
+
+func TestTryWithFinally_CleanupBeforeCatch(t *testing.T) {
+	var order []string
+
+	tb := TryWithFinally(func() { panic("boom") }, func() { order = append(order, "cleanup") })
+	Catch[string](tb, func(string) { order = append(order, "catch") })
+
+	if len(order) != 2 || order[0] != "cleanup" || order[1] != "catch" {
+		t.Errorf("Expected [cleanup catch], got %v", order)
+	}
+}
+
+func TestTryWithFinally_CleanupPanic(t *testing.T) {
+	tb := TryWithFinally(func() {}, func() { panic("cleanup") })
+
+	if tb.GetError() != "cleanup" {
+		t.Errorf("Expected cleanup panic to be captured, got %v", tb.GetError())
+	}
+}
+
+func TestTryWithFinally_BothPanicKeepsBoth(t *testing.T) {
+	tb := TryWithFinally(func() { panic("fn") }, func() { panic("cleanup") })
+
+	multi, ok := tb.GetError().(MultiError)
+	if !ok {
+		t.Fatalf("Expected MultiError, got %T: %v", tb.GetError(), tb.GetError())
+	}
+	if !reflect.DeepEqual(multi.Errors, []interface{}{"fn", "cleanup"}) {
+		t.Errorf("Expected [fn cleanup], got %v", multi.Errors)
+	}
+}
+
+func TestTryWithFinally_NilCleanup(t *testing.T) {
+	tb := TryWithFinally(func() { panic("boom") }, nil)
+
+	if tb.GetError() != "boom" {
+		t.Errorf("Expected 'boom', got %v", tb.GetError())
+	}
+}
+
+// ============================================
+// Safe / SafeErr 测试
+// ============================================
+
+func TestSafe(t *testing.T) {
+	var ran bool
+	safe := Safe(func() {
+		ran = true
+		panic("boom")
+	})
+
+	if SuppressPanics(safe) {
+		t.Error("Expected wrapped function not to propagate the panic")
+	}
+	if !ran {
+		t.Error("Expected wrapped function to run")
+	}
+}
+
+func TestSafeErr(t *testing.T) {
+	sentinel := errors.New("sentinel")
+
+	tests := []struct {
+		name string
+		fn   func() error
+		want string
+	}{
+		{"returns nil", func() error { return nil }, ""},
+		{"returns error", func() error { return sentinel }, "sentinel"},
+		{"panics with error", func() error { panic(sentinel) }, "sentinel"},
+		{"panics with value", func() error { panic(42) }, "panic: 42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if SuppressPanics(func() { err = SafeErr(tt.fn)() }) {
+				t.Fatal("Expected SafeErr not to propagate the panic")
+			}
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if err := SafeErr(func() error { panic(sentinel) })(); !errors.Is(err, sentinel) {
+		t.Errorf("Expected panicked error to be returned unchanged, got %v", err)
+	}
+}
+
+func TestWrapTask_WorkerSurvivesPanic(t *testing.T) {
+	tasks := make(chan func())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for task := range tasks {
+			task()
+		}
+	}()
+
+	panics := make(chan interface{}, 1)
+	var ranAfter bool
+	tasks <- WrapTask(func() { panic("task failed") }, func(v interface{}) { panics <- v })
+	tasks <- WrapTask(func() { ranAfter = true }, func(interface{}) { t.Error("Expected no panic") })
+	close(tasks)
+	<-done
+
+	if v := <-panics; v != "task failed" {
+		t.Errorf("Expected onPanic to receive the panic, got %v", v)
+	}
+	if !ranAfter {
+		t.Error("Expected the worker to keep running tasks after a panic")
+	}
+}
+
+func TestWrapTask_NilOnPanic(t *testing.T) {
+	if SuppressPanics(WrapTask(func() { panic("dropped") }, nil)) {
+		t.Error("Expected panic to be dropped with a nil onPanic")
+	}
+}
+
+// ============================================
+// TryPartial 测试
+// ============================================
+
+func TestTryPartial_PanicKeepsEmitted(t *testing.T) {
+	values, tb := TryPartial(func(emit func(int)) {
+		for i := 1; i <= 5; i++ {
+			if i == 4 {
+				panic("source closed")
+			}
+			emit(i * 10)
+		}
+	})
+
+	if len(values) != 3 || values[0] != 10 || values[2] != 30 {
+		t.Errorf("Expected partial values [10 20 30], got %v", values)
+	}
+	if tb.GetError() != "source closed" {
+		t.Errorf("Expected captured panic, got %v", tb.GetError())
+	}
+}
+
+func TestTryPartial_Success(t *testing.T) {
+	values, tb := TryPartial(func(emit func(string)) {
+		emit("a")
+		emit("b")
+	})
+
+	if len(values) != 2 || tb.HasError() {
+		t.Errorf("Expected [a b] without error, got %v and %v", values, tb)
+	}
+}
+
+// ============================================
+// TryCapture 测试
+// ============================================
+
+func TestTryCapture_PanicKeepsLines(t *testing.T) {
+	lines, tb := TryCapture(func(log func(string)) {
+		log("loading config")
+		log("connecting to db")
+		panic(errors.New("connection refused"))
+	})
+
+	if len(lines) != 2 || lines[0] != "loading config" || lines[1] != "connecting to db" {
+		t.Errorf("Expected both log lines, got %v", lines)
+	}
+	if tb.GetError() == nil || tb.GetError().(error).Error() != "connection refused" {
+		t.Errorf("Expected captured error, got %v", tb.GetError())
+	}
+}
+
+func TestTryCapture_NoLines(t *testing.T) {
+	lines, tb := TryCapture(func(log func(string)) {})
+
+	if lines != nil || tb.HasError() {
+		t.Errorf("Expected no lines and no error, got %v and %v", lines, tb)
+	}
+}
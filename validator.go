@@ -0,0 +1,66 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Validator - Fluent accumulation of validation failures
+// ============================================
+
+// Validator accumulates validation failures and throws them together.
+// The zero value is ready to use.
+//
+//	gotrycatch.NewValidator().
+//		Require(name != "", "name", "required", 1001).
+//		Require(age >= 0, "age", "must not be negative", 1002).
+//		Check()
+type Validator struct {
+	errs trycatcherrors.ValidationErrors
+}
+
+// NewValidator creates an empty Validator.
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// Require records a ValidationError for field if cond is false.
+// The error's location (and Location, when enabled) points at the caller of Require.
+// Returns the same Validator for chaining.
+func (v *Validator) Require(cond bool, field, msg string, code int) *Validator {
+	if cond {
+		return v
+	}
+
+	ve := trycatcherrors.NewValidationError(field, msg, code)
+	if pc, file, line, ok := runtime.Caller(1); ok {
+		ve.File, ve.Line = file, line
+		if ve.Location != "" {
+			ve.Location = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+		}
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			ve.Function = fn.Name()
+		}
+	}
+	v.errs = append(v.errs, ve)
+	return v
+}
+
+// Errors returns the accumulated failures, or nil if there are none.
+func (v *Validator) Errors() trycatcherrors.ValidationErrors {
+	return v.errs
+}
+
+// Check throws the accumulated failures as errors.ValidationErrors if any Require failed.
+// It does nothing if every requirement held.
+func (v *Validator) Check() {
+	if len(v.errs) == 0 {
+		return
+	}
+	debugLog("Validator: %d requirement(s) failed", len(v.errs))
+	Throw(v.errs)
+}
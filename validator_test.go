@@ -0,0 +1,94 @@
+package gotrycatch
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	trycatcherrors "github.com/linkerlin/gotrycatch/errors"
+)
+
+// ============================================
+// Validator 测试
+// ============================================
+
+func TestValidator_NoFailures(t *testing.T) {
+	tb := Try(func() {
+		NewValidator().
+			Require(true, "name", "required", 1001).
+			Require(1 > 0, "age", "must be positive", 1002).
+			Check()
+	})
+
+	if tb.HasError() {
+		t.Errorf("Expected no throw, got %v", tb.GetError())
+	}
+}
+
+func TestValidator_MultipleFailures(t *testing.T) {
+	name, age, email := "", -1, "ok@example.com"
+
+	var caught trycatcherrors.ValidationErrors
+	tb := Try(func() {
+		NewValidator().
+			Require(name != "", "name", "required", 1001).
+			Require(age >= 0, "age", "must not be negative", 1002).
+			Require(strings.Contains(email, "@"), "email", "invalid", 1003).
+			Check()
+	})
+	tb = Catch[trycatcherrors.ValidationErrors](tb, func(errs trycatcherrors.ValidationErrors) {
+		caught = errs
+	})
+
+	if !tb.IsHandled() {
+		t.Fatalf("Expected ValidationErrors to be thrown, got %T", tb.GetError())
+	}
+	if len(caught) != 2 {
+		t.Fatalf("Expected 2 failures, got %d", len(caught))
+	}
+	fields := caught.Fields()
+	if fields[0] != "name" || fields[1] != "age" {
+		t.Errorf("Expected [name age], got %v", fields)
+	}
+	if caught[1].Code != 1002 {
+		t.Errorf("Expected code 1002, got %d", caught[1].Code)
+	}
+	if !strings.HasSuffix(caught[0].File, "validator_test.go") {
+		t.Errorf("Expected location at the Require call site, got %s", caught[0].File)
+	}
+	if caught.Error() != "2 validation errors: name: required; age: must not be negative" {
+		t.Errorf("Unexpected message: %s", caught.Error())
+	}
+}
+
+func TestValidator_ZeroValueAndErrors(t *testing.T) {
+	var v Validator
+	if v.Errors() != nil {
+		t.Error("Expected no errors on a fresh Validator")
+	}
+
+	v.Require(false, "x", "bad", 1)
+	if len(v.Errors()) != 1 {
+		t.Errorf("Expected 1 accumulated error, got %d", len(v.Errors()))
+	}
+
+	tb := Try(v.Check)
+	tb = CatchChain[trycatcherrors.ValidationError](tb, func(err trycatcherrors.ValidationError) {})
+	if !tb.IsHandled() {
+		t.Error("Expected individual ValidationError to be reachable via CatchChain")
+	}
+}
+
+func TestValidator_RequireLocation(t *testing.T) {
+	trycatcherrors.CaptureLocation = true
+	defer func() { trycatcherrors.CaptureLocation = false }()
+
+	_, _, line, _ := runtime.Caller(0)
+	errs := NewValidator().Require(false, "name", "required", 1001).Errors()
+
+	want := fmt.Sprintf("validator_test.go:%d", line+1)
+	if errs[0].Where() != want {
+		t.Errorf("Expected location %s, got %s", want, errs[0].Where())
+	}
+}
@@ -0,0 +1,137 @@
+package gotrycatch
+
+import (
+	"sync"
+	"time"
+)
+
+// ============================================
+// WindowAggregator - Batched alerting over time windows
+// ============================================
+
+// WindowSummary describes the errors added to a WindowAggregator during one window.
+type WindowSummary struct {
+	Start  time.Time      // Start of the window
+	End    time.Time      // When the window was flushed
+	Total  int            // Number of errors added during the window
+	Groups []CountedError // Distinct errors by Fingerprint, in order of first occurrence
+}
+
+// WindowAggregator buffers caught errors and periodically flushes a summary grouped by
+// Fingerprint, so a burst of identical failures raises one alert instead of hundreds.
+// Windows without errors are not flushed. A WindowAggregator is safe for concurrent use.
+//
+//	agg := gotrycatch.NewWindowAggregator(time.Minute, sendAlert)
+//	agg.Start()
+//	defer agg.Stop()
+//	...
+//	tb.CatchAny(agg.Add)
+type WindowAggregator struct {
+	interval  time.Duration
+	flush     func(WindowSummary)
+	now       func() time.Time                               // injectable clock for tests
+	newTicker func(time.Duration) (<-chan time.Time, func()) // injectable ticker for tests
+
+	mu          sync.Mutex
+	pending     Collector
+	windowStart time.Time
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// NewWindowAggregator creates an aggregator that, once started, calls flush with a
+// summary every interval. A nil flush discards the summaries.
+func NewWindowAggregator(interval time.Duration, flush func(WindowSummary)) *WindowAggregator {
+	return &WindowAggregator{
+		interval: interval,
+		flush:    flush,
+		now:      time.Now,
+		newTicker: func(d time.Duration) (<-chan time.Time, func()) {
+			t := time.NewTicker(d)
+			return t.C, t.Stop
+		},
+	}
+}
+
+// Add buffers err for the current window. Nil values are ignored.
+// Add has the shape of a catch handler, so it can be passed to CatchAny directly.
+func (a *WindowAggregator) Add(err interface{}) {
+	if err == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.windowStart.IsZero() {
+		a.windowStart = a.now()
+	}
+	a.pending.Add(err)
+}
+
+// Start begins flushing every interval in a background goroutine.
+// Calling Start on a running aggregator has no effect.
+func (a *WindowAggregator) Start() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stop != nil {
+		return
+	}
+	if a.windowStart.IsZero() {
+		a.windowStart = a.now()
+	}
+
+	a.stop = make(chan struct{})
+	a.done = make(chan struct{})
+	ticks, stopTicker := a.newTicker(a.interval)
+	go a.run(ticks, stopTicker, a.stop, a.done)
+}
+
+func (a *WindowAggregator) run(ticks <-chan time.Time, stopTicker func(), stop, done chan struct{}) {
+	defer close(done)
+	defer stopTicker()
+	for {
+		select {
+		case <-ticks:
+			a.Flush()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Stop ends background flushing and flushes the errors buffered so far.
+// Calling Stop on an aggregator that is not running only flushes.
+func (a *WindowAggregator) Stop() {
+	a.mu.Lock()
+	stop, done := a.stop, a.done
+	a.stop, a.done = nil, nil
+	a.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	a.Flush()
+}
+
+// Flush ends the current window, calling the flush callback with its summary if any
+// errors were added, and starts a new window.
+func (a *WindowAggregator) Flush() {
+	a.mu.Lock()
+	now := a.now()
+	summary := WindowSummary{
+		Start:  a.windowStart,
+		End:    now,
+		Total:  a.pending.Len(),
+		Groups: a.pending.Deduped(),
+	}
+	a.pending.Reset()
+	a.windowStart = now
+	a.mu.Unlock()
+
+	if summary.Total == 0 || a.flush == nil {
+		return
+	}
+	debugLog("WindowAggregator: flushing %d error(s) in %d group(s)", summary.Total, len(summary.Groups))
+	a.flush(summary)
+}
@@ -0,0 +1,98 @@
+package gotrycatch
+
+import (
+	"testing"
+	"time"
+)
+
+// ============================================
+// WindowAggregator 测试
+// ============================================
+
+func newTestAggregator(clock *fakeClock) (*WindowAggregator, chan WindowSummary, chan time.Time) {
+	summaries := make(chan WindowSummary, 10)
+	agg := NewWindowAggregator(time.Minute, func(s WindowSummary) { summaries <- s })
+	agg.now = clock.Now
+
+	ticks := make(chan time.Time)
+	agg.newTicker = func(time.Duration) (<-chan time.Time, func()) { return ticks, func() {} }
+	return agg, summaries, ticks
+}
+
+func TestWindowAggregator_FlushesPerWindow(t *testing.T) {
+	clock := newFakeClock()
+	agg, summaries, ticks := newTestAggregator(clock)
+	start := clock.Now()
+	agg.Start()
+
+	for i := 0; i < 5; i++ {
+		agg.Add("db timeout")
+	}
+	agg.Add("cache miss")
+	agg.Add(nil)
+	clock.Advance(time.Minute)
+	ticks <- clock.Now()
+
+	first := <-summaries
+	if first.Total != 6 || len(first.Groups) != 2 {
+		t.Errorf("Expected 6 errors in 2 groups, got %+v", first)
+	}
+	if first.Groups[0].Err != "db timeout" || first.Groups[0].Count != 5 || first.Groups[1].Count != 1 {
+		t.Errorf("Expected db timeout x5 and cache miss x1, got %+v", first.Groups)
+	}
+	if !first.Start.Equal(start) || !first.End.Equal(start.Add(time.Minute)) {
+		t.Errorf("Unexpected first window %v - %v", first.Start, first.End)
+	}
+
+	agg.Add("cache miss")
+	clock.Advance(30 * time.Second)
+	agg.Stop()
+
+	second := <-summaries
+	if second.Total != 1 || second.Groups[0].Err != "cache miss" {
+		t.Errorf("Expected one cache miss in the final flush, got %+v", second)
+	}
+	if !second.Start.Equal(start.Add(time.Minute)) || !second.End.Equal(start.Add(90*time.Second)) {
+		t.Errorf("Unexpected second window %v - %v", second.Start, second.End)
+	}
+}
+
+func TestWindowAggregator_EmptyWindowNotFlushed(t *testing.T) {
+	clock := newFakeClock()
+	agg, summaries, _ := newTestAggregator(clock)
+
+	agg.Flush()
+	agg.Add("boom")
+	agg.Stop()
+	agg.Stop()
+
+	if len(summaries) != 1 {
+		t.Fatalf("Expected one summary, got %d", len(summaries))
+	}
+	if s := <-summaries; s.Total != 1 {
+		t.Errorf("Expected Stop to flush the buffered error, got %+v", s)
+	}
+}
+
+func TestWindowAggregator_RealTicker(t *testing.T) {
+	flushed := make(chan WindowSummary, 1)
+	agg := NewWindowAggregator(time.Millisecond, func(s WindowSummary) {
+		select {
+		case flushed <- s:
+		default:
+		}
+	})
+	agg.Start()
+	agg.Start()
+	defer agg.Stop()
+
+	agg.Add("tick")
+	select {
+	case s := <-flushed:
+		if s.Total != 1 {
+			t.Errorf("Expected one error, got %+v", s)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a flush from the ticker")
+	}
+}